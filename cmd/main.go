@@ -1,30 +1,68 @@
 package main
 
 import (
-	"io"
-	"log"
+	"flag"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/cli"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
 	"github.com/mr-ministry/mr-verse/internal/ui"
 )
 
+// logFlags are the flags that configure the application log
+type logFlags struct {
+	level  string
+	format string
+}
+
 func main() {
 	// Load environment variables
 	loadEnv()
 
-	// Set up logging
-	setupLogging()
+	// Load the configuration file and resolve where files are kept
+	args, logConfig := parseFlags()
+	isCommand := len(args) > 0
+	configErr := loadConfig()
+
+	// Bring over files from older versions before the log folder is
+	// created, since it can be inside the home directory
+	copied, migrateErr := appdir.Migrate()
+
+	closeLog := setupLogging(logConfig, isCommand)
+	if configErr != nil {
+		slog.Warn("Using the default configuration", "error", configErr)
+	}
+	logMigration(copied, migrateErr)
+	loadThemes()
+
+	// Run a command line command instead of the UI if one is given
+	if isCommand {
+		if err := cli.Run(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Create data directory if it doesn't exist
+	// Create the translations directory if it doesn't exist
 	ensureDataDirectory()
+	writeConfig()
 
 	// Run the application
-	log.Println("Starting Mr Verse application")
+	slog.Info("Starting Mr Verse application")
 	ui.RunApp()
 
-	log.Println("Application shutdown complete")
+	slog.Info("Application shutdown complete")
+	closeLog()
 }
 
 // loadEnv loads environment variables from .env file
@@ -32,53 +70,159 @@ func loadEnv() {
 	envFile := ".env"
 	if _, err := os.Stat(envFile); err == nil {
 		if err := godotenv.Load(envFile); err != nil {
-			log.Printf("Warning: Error loading .env file: %v", err)
+			slog.Warn("Could not load .env file", "error", err)
 		} else {
-			log.Println("Environment variables loaded from .env file")
+			slog.Debug("Environment variables loaded from .env file")
 		}
 	}
 }
 
-// setupLogging configures the application logging
-func setupLogging() {
-	// Create logs directory if it doesn't exist
-	logsDir := "logs"
-	if err := os.MkdirAll(logsDir, 0755); err != nil {
-		log.Printf("Warning: Could not create logs directory: %v", err)
-		return
+// parseFlags reads the flags that override where files are kept and how
+// logging is set up, and returns the remaining arguments
+func parseFlags() ([]string, logFlags) {
+	var overrides appdir.Overrides
+	flag.StringVar(&overrides.Config, "config", "", "path of the configuration file (env MRVERSE_CONFIG)")
+	flag.StringVar(&overrides.Home, "home", "", "base directory of the application's files (env MRVERSE_HOME)")
+	flag.StringVar(&overrides.DB, "db", "", "path of the database file (env DB_PATH)")
+	flag.StringVar(&overrides.Translations, "translations", "", "folder of Bible translation JSON files (env TRANSLATIONS_DIR)")
+	flag.StringVar(&overrides.Logs, "logs", "", "folder of log files (env LOG_DIR)")
+	flag.StringVar(&overrides.Media, "media", "", "media library folder (env MEDIA_DIR)")
+
+	var logConfig logFlags
+	flag.StringVar(&logConfig.level, "log-level", "", "log level: debug, info, warn or error (env LOG_LEVEL, default info)")
+	flag.StringVar(&logConfig.format, "log-format", "", "log format: text or json (env LOG_FORMAT, default text)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command [arguments]]\n\nFlags:\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nRun '%s help' for the commands.\n", filepath.Base(os.Args[0]))
 	}
+	flag.Parse()
 
-	// Set up log file
-	logFile := filepath.Join(logsDir, "mr-verse.log")
-	f, err := os.OpenFile(logFile, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	appdir.SetOverrides(overrides)
+	return flag.Args(), logConfig
+}
+
+// loadConfig loads the configuration file. An invalid file is ignored and
+// its error returned, so it can be logged once logging is set up.
+func loadConfig() error {
+	err := config.LoadCurrent(appdir.ConfigFile())
+	appdir.SetConfigured(config.Current().Paths.Overrides())
+	return err
+}
+
+// writeConfig writes a configuration file with the defaults in comments
+// the first time the application runs, to show what can be configured
+func writeConfig() {
+	path := appdir.ConfigFile()
+	written, err := config.WriteDefault(path)
 	if err != nil {
-		log.Printf("Warning: Could not open log file: %v", err)
-		return
+		slog.Warn("Could not write configuration file", "file", path, "error", err)
+	} else if written {
+		slog.Info("Wrote configuration file", "file", path)
+	}
+}
+
+// setupLogging configures the application log. The application logs to the
+// console and to a rotating log file; commands log to stderr only, so their
+// output stays clean. It returns a function that closes the log file.
+func setupLogging(flags logFlags, isCommand bool) func() error {
+	options := logging.DefaultOptions()
+	if isCommand {
+		options.Console = os.Stderr
+	} else {
+		options.Dir = appdir.LogsDir()
+	}
+
+	var problems []string
+	configured := config.Current().Logging
+	if flags.level != "" || os.Getenv("LOG_LEVEL") != "" {
+		config.SetFixed("logging.level")
+	}
+	if level := setting(flags.level, "LOG_LEVEL", configured.Level); level != "" {
+		if parsed, err := logging.ParseLevel(level); err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			options.Level = parsed
+		}
+	}
+	if format := setting(flags.format, "LOG_FORMAT", configured.Format); format != "" {
+		if slices.Contains(logging.Formats, format) {
+			options.Format = format
+		} else {
+			problems = append(problems, "unsupported log format: "+format)
+		}
 	}
 
-	// Create a multi-writer to log to both file and stdout
-	multiWriter := io.MultiWriter(os.Stdout, f)
+	closeLog, err := logging.Setup(options)
+	if err != nil {
+		// Keep logging to the console
+		options.Dir = ""
+		closeLog, _ = logging.Setup(options)
+		slog.Warn("Could not open log file", "error", err)
+	}
+	for _, problem := range problems {
+		slog.Warn("Ignoring log setting", "error", problem)
+	}
 
-	// // Configure the logger to use the multi-writer
-	log.SetOutput(multiWriter)
-	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
+	if file := logging.File(); file != "" {
+		slog.Info("Logging configured", "file", file, "level", options.Level, "format", options.Format)
+	}
+	slog.Debug("Configuration file", "file", appdir.ConfigFile())
+	return closeLog
+}
 
-	log.Println("Logging configured to write to both console and", logFile)
+// setting returns the value of a flag, or else of an environment variable,
+// or else of the configuration file
+func setting(flagValue, env, fromConfig string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	return fromConfig
+}
+
+// logMigration logs the database, translations and media copied from the
+// working directory the first time the per-user directories are used
+func logMigration(copied []string, err error) {
+	if err != nil {
+		slog.Warn("Could not copy files from an earlier version; trying again at the next start", "home", appdir.Home(), "error", err)
+	}
+	for _, file := range copied {
+		slog.Info("Copied file from an earlier version", "file", file, "home", appdir.Home())
+	}
+}
+
+// loadThemes adds the slide themes of the themes folder, and then those of
+// the configuration file
+func loadThemes() {
+	names, err := presentation.LoadThemes(appdir.ThemesDir())
+	if err != nil {
+		slog.Warn("Could not load themes", "error", err)
+	}
+	if len(names) > 0 {
+		slog.Info("Loaded themes", "themes", strings.Join(names, ", "), "dir", appdir.ThemesDir())
+	}
+	if names := config.Current().AddThemes(); len(names) > 0 {
+		slog.Info("Loaded themes", "themes", strings.Join(names, ", "), "file", appdir.ConfigFile())
+	}
 }
 
-// ensureDataDirectory creates the data directory if it doesn't exist
+// ensureDataDirectory creates the translations directory if it doesn't exist
 func ensureDataDirectory() {
-	dataDir := "data"
+	dataDir := appdir.TranslationsDir()
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		log.Fatalf("Error: Could not create data directory: %v", err)
+		slog.Error("Could not create translations directory", "dir", dataDir, "error", err)
+		os.Exit(1)
 	}
 
 	// Check if there are any Bible translation files
 	files, err := filepath.Glob(filepath.Join(dataDir, "*.json"))
 	if err != nil {
-		log.Printf("Warning: Could not check for Bible translation files: %v", err)
+		slog.Warn("Could not check for Bible translation files", "error", err)
 	} else if len(files) == 0 {
-		log.Println("Warning: No Bible translation files found in the data directory.")
-		log.Println("Please add at least one Bible translation JSON file to the data directory.")
+		slog.Warn("No Bible translation files found; add a translation JSON file there or import one from the controller", "dir", dataDir)
 	}
 }
@@ -0,0 +1,255 @@
+// Package appdir resolves where Mr Verse keeps its files for the current user:
+// the configuration file, the database, translation files, logs, media, themes
+// and playlists
+package appdir
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// appName is the name of the application's directories
+const appName = "mr-verse"
+
+// Overrides replace locations of the layout, e.g. from command line flags.
+// Empty fields fall back to the environment, then to the configuration file
+// and then to the default layout.
+type Overrides struct {
+	Config       string // Configuration file; not read from the configuration file
+	Home         string // Base directory of everything not set separately
+	DB           string
+	Translations string
+	Logs         string
+	Media        string
+}
+
+// overrides are the locations set with SetOverrides
+var overrides Overrides
+
+// configured are the locations set with SetConfigured
+var configured Overrides
+
+// SetOverrides sets locations that take precedence over the environment
+func SetOverrides(o Overrides) {
+	overrides = o
+}
+
+// SetConfigured sets locations from the configuration file, which the
+// environment takes precedence over
+func SetConfigured(o Overrides) {
+	configured = o
+}
+
+// ConfigFile returns the path of the configuration file, set with MRVERSE_CONFIG.
+// It defaults to $XDG_CONFIG_HOME/mr-verse/config.toml (~/.config/mr-verse/config.toml)
+// on Linux and config.toml in the home directory's default location elsewhere.
+func ConfigFile() string {
+	if path := setting(overrides.Config, "MRVERSE_CONFIG", ""); path != "" {
+		return path
+	}
+	return filepath.Join(userDir("XDG_CONFIG_HOME", ".config"), appName, "config.toml")
+}
+
+// Home returns the base directory of the application's files. It is set with
+// MRVERSE_HOME and defaults to the per-user data directory: $XDG_DATA_HOME/mr-verse
+// (~/.local/share/mr-verse) on Linux, ~/Library/Application Support/mr-verse on
+// macOS and %AppData%\mr-verse on Windows.
+func Home() string {
+	if dir := setting(overrides.Home, "MRVERSE_HOME", configured.Home); dir != "" {
+		return dir
+	}
+	return filepath.Join(userDir("XDG_DATA_HOME", ".local", "share"), appName)
+}
+
+// DBPath returns the path of the SQLite database, set with DB_PATH
+func DBPath() string {
+	if path := setting(overrides.DB, "DB_PATH", configured.DB); path != "" {
+		return path
+	}
+	return filepath.Join(Home(), "bible.db")
+}
+
+// TranslationsDir returns the folder of Bible translation JSON files, set with TRANSLATIONS_DIR
+func TranslationsDir() string {
+	if dir := setting(overrides.Translations, "TRANSLATIONS_DIR", configured.Translations); dir != "" {
+		return dir
+	}
+	return filepath.Join(Home(), "translations")
+}
+
+// LogsDir returns the folder of log files, set with LOG_DIR. On Linux it
+// defaults to $XDG_STATE_HOME/mr-verse/logs unless the home is set.
+func LogsDir() string {
+	if dir := setting(overrides.Logs, "LOG_DIR", configured.Logs); dir != "" {
+		return dir
+	}
+	if setting(overrides.Home, "MRVERSE_HOME", configured.Home) != "" || !isXDG() {
+		return filepath.Join(Home(), "logs")
+	}
+	return filepath.Join(userDir("XDG_STATE_HOME", ".local", "state"), appName, "logs")
+}
+
+// MediaDir returns the media library folder, set with MEDIA_DIR
+func MediaDir() string {
+	if dir := setting(overrides.Media, "MEDIA_DIR", configured.Media); dir != "" {
+		return dir
+	}
+	return filepath.Join(Home(), "media")
+}
+
+// ThemesDir returns the folder of slide theme files
+func ThemesDir() string {
+	return filepath.Join(Home(), "themes")
+}
+
+// PlaylistsDir returns the folder exported playlists are saved in by default
+func PlaylistsDir() string {
+	return filepath.Join(Home(), "playlists")
+}
+
+// migratedFile marks a home directory the files of earlier versions were copied to
+const migratedFile = ".migrated"
+
+// Migrate copies the database, translation files and media from the working
+// directory, where earlier versions kept them, into the home directory.
+// Locations set explicitly are left alone. A marker file is written once all
+// files are copied, so a migration that failed halfway is tried again at the
+// next start. It returns the files it copied; the originals are kept.
+func Migrate() ([]string, error) {
+	home := Home()
+	marker := filepath.Join(home, migratedFile)
+	if _, err := os.Stat(marker); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(home, 0755); err != nil {
+		return nil, err
+	}
+
+	var copied []string
+	migrate := func(src, dst string) error {
+		if _, err := os.Stat(dst); err == nil {
+			return nil // Never overwrite, e.g. files copied before a failure
+		}
+		if err := CopyFile(src, dst); err != nil {
+			return err
+		}
+		copied = append(copied, src)
+		return nil
+	}
+
+	// The database was ./bible.db, or ./data/bible.db as the example .env suggested
+	if setting(overrides.DB, "DB_PATH", configured.DB) == "" {
+		for _, old := range []string{"bible.db", filepath.Join("data", "bible.db")} {
+			if _, err := os.Stat(old); err == nil {
+				if err := migrate(old, DBPath()); err != nil {
+					return copied, err
+				}
+				break
+			}
+		}
+	}
+
+	if setting(overrides.Translations, "TRANSLATIONS_DIR", configured.Translations) == "" {
+		files, err := filepath.Glob(filepath.Join("data", "*.json"))
+		if err != nil {
+			return copied, err
+		}
+		for _, file := range files {
+			if err := migrate(file, filepath.Join(TranslationsDir(), filepath.Base(file))); err != nil {
+				return copied, err
+			}
+		}
+	}
+
+	if setting(overrides.Media, "MEDIA_DIR", configured.Media) == "" {
+		entries, err := os.ReadDir("media")
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return copied, err
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				if err := migrate(filepath.Join("media", entry.Name()), filepath.Join(MediaDir(), entry.Name())); err != nil {
+					return copied, err
+				}
+			}
+		}
+	}
+
+	return copied, os.WriteFile(marker, nil, 0644)
+}
+
+// CopyFile copies the file at src to dst, creating its directory.
+// The copy is renamed into place once complete, so dst is never left half written.
+func CopyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = out.Chmod(0644)
+		}
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(out.Name(), dst)
+		}
+		if err != nil {
+			os.Remove(out.Name())
+		}
+	}()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// setting returns an override, or else the environment variable,
+// or else the location from the configuration file
+func setting(override, env, fromConfig string) string {
+	if override != "" {
+		return override
+	}
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	return fromConfig
+}
+
+// isXDG reports whether the platform follows the XDG base directory layout
+func isXDG() bool {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "android", "js", "wasip1", "plan9":
+		return false
+	}
+	return true
+}
+
+// userDir returns a per-user base directory: the XDG directory in env with
+// its default below the home directory on XDG platforms, and the user
+// configuration directory elsewhere. The working directory is the last resort.
+func userDir(env string, fallback ...string) string {
+	if isXDG() {
+		if dir := os.Getenv(env); filepath.IsAbs(dir) {
+			return dir
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(append([]string{home}, fallback...)...)
+		}
+	} else if dir, err := os.UserConfigDir(); err == nil {
+		return dir
+	}
+	return "."
+}
@@ -0,0 +1,195 @@
+package bible
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// BookAlias is a name, abbreviation or alternate spelling of a book in a language
+type BookAlias struct {
+	Language string `json:"language"`
+	Book     string `json:"book"`
+	Alias    string `json:"alias"`
+	Kind     string `json:"kind"`
+}
+
+// SeedBookAliases stores the built-in book names, abbreviations and alternate spellings.
+// Safe to run multiple times thanks to INSERT OR IGNORE.
+func SeedBookAliases() (err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(`
+		INSERT OR IGNORE INTO book_aliases (language, book, alias, normalized, kind, builtin)
+		VALUES (?, ?, ?, ?, ?, 1)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	// English names are the canonical names plus their abbreviations
+	for _, book := range CanonicalBooks {
+		if _, err = stmt.Exec(DefaultLanguage, book, book, NormalizeBookName(book), AliasKindName); err != nil {
+			return err
+		}
+		for _, alias := range englishAliases[book] {
+			if _, err = stmt.Exec(DefaultLanguage, book, alias, NormalizeBookName(alias), AliasKindAlias); err != nil {
+				return err
+			}
+		}
+	}
+
+	for language, names := range localizedBookNames {
+		for i, name := range names {
+			book := CanonicalBooks[i]
+			if _, err = stmt.Exec(language, book, name, NormalizeBookName(name), AliasKindName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	dropBookCache()
+	return nil
+}
+
+// AddBookAlias stores a name or abbreviation of a book in a language.
+// An existing alias with the same normalized spelling is replaced.
+func AddBookAlias(alias BookAlias) error {
+	if alias.Kind != AliasKindName && alias.Kind != AliasKindAlias {
+		return fmt.Errorf("invalid alias kind: %s", alias.Kind)
+	}
+
+	_, err := DB.Exec(`
+		INSERT OR REPLACE INTO book_aliases (language, book, alias, normalized, kind, builtin)
+		VALUES (?, ?, ?, ?, ?, 0)
+	`, alias.Language, alias.Book, alias.Alias, NormalizeBookName(alias.Alias), alias.Kind)
+	if err != nil {
+		return err
+	}
+	dropBookCache()
+	return nil
+}
+
+// GetBookAliases returns every alias of every book, e.g. for autocompletion
+func GetBookAliases() ([]BookAlias, error) {
+	rows, err := DB.Query(`
+		SELECT language, book, alias, kind
+		FROM book_aliases
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []BookAlias
+	for rows.Next() {
+		var a BookAlias
+		if err := rows.Scan(&a.Language, &a.Book, &a.Alias, &a.Kind); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+
+	return aliases, rows.Err()
+}
+
+// ResolveBookName resolves a full name, abbreviation or alternate spelling in any
+// language to the canonical book name. Aliases in preferLanguage win over other
+// languages. Returns ok=false when the name is not known.
+func ResolveBookName(name, preferLanguage string) (book string, ok bool, err error) {
+	row := DB.QueryRow(`
+		SELECT book
+		FROM book_aliases
+		WHERE normalized = ?
+		ORDER BY language = ? DESC, language = ? DESC, id
+		LIMIT 1
+	`, NormalizeBookName(name), preferLanguage, DefaultLanguage)
+
+	if scanErr := row.Scan(&book); scanErr != nil {
+		if scanErr == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, scanErr
+	}
+	return book, true, nil
+}
+
+// GetTranslationLanguage returns the language of a translation,
+// or DefaultLanguage when it is not recorded
+func GetTranslationLanguage(translation string) (string, error) {
+	var language string
+	err := DB.QueryRow("SELECT language FROM translations WHERE name = ?", translation).
+		Scan(&language)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DefaultLanguage, nil
+		}
+		return "", err
+	}
+	return language, nil
+}
+
+// GetLocalizedBookName returns the name of a book in the language of a translation.
+// Names from the translation's JSON file win over the built-in ones, and the
+// canonical name is returned when there is no localized name.
+func GetLocalizedBookName(translation, book string) (string, error) {
+	language, err := GetTranslationLanguage(translation)
+	if err != nil {
+		return book, err
+	}
+
+	var name string
+	err = DB.QueryRow(`
+		SELECT alias
+		FROM book_aliases
+		WHERE language = ? AND book = ? AND kind = ?
+		ORDER BY builtin, id
+		LIMIT 1
+	`, language, book, AliasKindName).Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return book, nil
+		}
+		return book, err
+	}
+	return name, nil
+}
+
+// saveTranslationInfo stores the language and book names of a translation from its JSON file
+func saveTranslationInfo(tx *sql.Tx, translation string, bibleData *BibleData) error {
+	language := bibleData.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO translations (name, language) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET language = excluded.language
+	`, translation, language)
+	if err != nil {
+		return err
+	}
+
+	for book, name := range bibleData.BookNames {
+		_, err := tx.Exec(`
+			INSERT OR REPLACE INTO book_aliases (language, book, alias, normalized, kind, builtin)
+			VALUES (?, ?, ?, ?, ?, 0)
+		`, language, book, name, NormalizeBookName(name), AliasKindName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package bible
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CanonicalBooks lists the canonical book names in Bible order.
+// These are the names used in the bible table and as keys in the JSON files.
+var CanonicalBooks = []string{
+	"Genesis", "Exodus", "Leviticus", "Numbers", "Deuteronomy",
+	"Joshua", "Judges", "Ruth", "1st Samuel", "2nd Samuel",
+	"1st Kings", "2nd Kings", "1st Chronicles", "2nd Chronicles", "Ezra",
+	"Nehemiah", "Esther", "Job", "Psalms", "Proverbs",
+	"Ecclesiastes", "Song of Solomon", "Isaiah", "Jeremiah", "Lamentations",
+	"Ezekiel", "Daniel", "Hosea", "Joel", "Amos",
+	"Obadiah", "Jonah", "Micah", "Nahum", "Habakkuk",
+	"Zephaniah", "Haggai", "Zechariah", "Malachi",
+	"Matthew", "Mark", "Luke", "John", "Acts",
+	"Romans", "1st Corinthians", "2nd Corinthians", "Galatians", "Ephesians",
+	"Philippians", "Colossians", "1st Thessalonians", "2nd Thessalonians", "1st Timothy",
+	"2nd Timothy", "Titus", "Philemon", "Hebrews", "James",
+	"1st Peter", "2nd Peter", "1st John", "2nd John", "3rd John",
+	"Jude", "Revelation",
+}
+
+// DefaultLanguage is the language of translations that don't specify one
+const DefaultLanguage = "en"
+
+// Kinds of book aliases
+const (
+	AliasKindName  = "name"  // The full book name used when displaying references
+	AliasKindAlias = "alias" // Abbreviations and alternate spellings
+)
+
+// englishAliases lists abbreviations and alternate spellings of the canonical books
+var englishAliases = map[string][]string{
+	"Genesis":           {"Gen", "Ge", "Gn"},
+	"Exodus":            {"Exod", "Exo", "Ex"},
+	"Leviticus":         {"Lev", "Le", "Lv"},
+	"Numbers":           {"Num", "Nu", "Nm", "Nb"},
+	"Deuteronomy":       {"Deut", "Deu", "Dt"},
+	"Joshua":            {"Josh", "Jos", "Jsh"},
+	"Judges":            {"Judg", "Jdg", "Jg", "Jdgs"},
+	"Ruth":              {"Rth", "Ru"},
+	"1st Samuel":        {"1 Samuel", "1 Sam", "1 Sa", "1 Sm"},
+	"2nd Samuel":        {"2 Samuel", "2 Sam", "2 Sa", "2 Sm"},
+	"1st Kings":         {"1 Kings", "1 Kgs", "1 Ki"},
+	"2nd Kings":         {"2 Kings", "2 Kgs", "2 Ki"},
+	"1st Chronicles":    {"1 Chronicles", "1 Chron", "1 Chr", "1 Ch"},
+	"2nd Chronicles":    {"2 Chronicles", "2 Chron", "2 Chr", "2 Ch"},
+	"Ezra":              {"Ezr", "Ez"},
+	"Nehemiah":          {"Neh", "Ne"},
+	"Esther":            {"Esth", "Est", "Es"},
+	"Job":               {"Jb"},
+	"Psalms":            {"Psalm", "Ps", "Psa", "Pss", "Psm"},
+	"Proverbs":          {"Prov", "Pro", "Prv", "Pr"},
+	"Ecclesiastes":      {"Eccl", "Eccles", "Ecc", "Qoheleth"},
+	"Song of Solomon":   {"Song of Songs", "Song", "Sos", "So", "Canticles"},
+	"Isaiah":            {"Isa", "Is"},
+	"Jeremiah":          {"Jer", "Je", "Jr"},
+	"Lamentations":      {"Lam", "La"},
+	"Ezekiel":           {"Ezek", "Eze", "Ezk"},
+	"Daniel":            {"Dan", "Da", "Dn"},
+	"Hosea":             {"Hos", "Ho"},
+	"Joel":              {"Jl"},
+	"Amos":              {"Am"},
+	"Obadiah":           {"Obad", "Ob"},
+	"Jonah":             {"Jon", "Jnh"},
+	"Micah":             {"Mic", "Mc"},
+	"Nahum":             {"Nah", "Na"},
+	"Habakkuk":          {"Hab", "Hb"},
+	"Zephaniah":         {"Zeph", "Zep", "Zp"},
+	"Haggai":            {"Hag", "Hg"},
+	"Zechariah":         {"Zech", "Zec", "Zc"},
+	"Malachi":           {"Mal", "Ml"},
+	"Matthew":           {"Matt", "Mat", "Mt"},
+	"Mark":              {"Mrk", "Mar", "Mk", "Mr"},
+	"Luke":              {"Luk", "Lk"},
+	"John":              {"Joh", "Jhn", "Jn"},
+	"Acts":              {"Act", "Ac"},
+	"Romans":            {"Rom", "Ro", "Rm"},
+	"1st Corinthians":   {"1 Corinthians", "1 Cor", "1 Co"},
+	"2nd Corinthians":   {"2 Corinthians", "2 Cor", "2 Co"},
+	"Galatians":         {"Gal", "Ga"},
+	"Ephesians":         {"Eph", "Ephes"},
+	"Philippians":       {"Phil", "Php", "Pp"},
+	"Colossians":        {"Col", "Co"},
+	"1st Thessalonians": {"1 Thessalonians", "1 Thess", "1 Thes", "1 Th"},
+	"2nd Thessalonians": {"2 Thessalonians", "2 Thess", "2 Thes", "2 Th"},
+	"1st Timothy":       {"1 Timothy", "1 Tim", "1 Ti"},
+	"2nd Timothy":       {"2 Timothy", "2 Tim", "2 Ti"},
+	"Titus":             {"Tit", "Ti"},
+	"Philemon":          {"Philem", "Phm", "Pm"},
+	"Hebrews":           {"Heb"},
+	"James":             {"Jas", "Jm"},
+	"1st Peter":         {"1 Peter", "1 Pet", "1 Pe", "1 Pt"},
+	"2nd Peter":         {"2 Peter", "2 Pet", "2 Pe", "2 Pt"},
+	"1st John":          {"1 John", "1 Jn", "1 Jhn", "1 Jo"},
+	"2nd John":          {"2 John", "2 Jn", "2 Jhn", "2 Jo"},
+	"3rd John":          {"3 John", "3 Jn", "3 Jhn", "3 Jo"},
+	"Jude":              {"Jud", "Jd"},
+	"Revelation":        {"Rev", "Re", "Revelations", "The Revelation"},
+}
+
+// localizedBookNames lists the full book names per language, in the order of CanonicalBooks
+var localizedBookNames = map[string][]string{
+	// Cebuano (Ang Biblia)
+	"ceb": {
+		"Genesis", "Exodo", "Levitico", "Numeros", "Deuteronomio",
+		"Josue", "Mga Maghuhukom", "Ruth", "1 Samuel", "2 Samuel",
+		"1 Mga Hari", "2 Mga Hari", "1 Mga Cronicas", "2 Mga Cronicas", "Esdras",
+		"Nehemias", "Ester", "Job", "Mga Salmo", "Mga Proverbio",
+		"Ecclesiastes", "Awit ni Solomon", "Isaias", "Jeremias", "Mga Lamentaciones",
+		"Ezequiel", "Daniel", "Oseas", "Joel", "Amos",
+		"Abdias", "Jonas", "Miqueas", "Nahum", "Habacuc",
+		"Sofonias", "Hageo", "Zacarias", "Malaquias",
+		"Mateo", "Marcos", "Lucas", "Juan", "Mga Buhat",
+		"Mga Taga-Roma", "1 Mga Taga-Corinto", "2 Mga Taga-Corinto", "Mga Taga-Galacia", "Mga Taga-Efeso",
+		"Mga Taga-Filipos", "Mga Taga-Colosas", "1 Mga Taga-Tesalonica", "2 Mga Taga-Tesalonica", "1 Timoteo",
+		"2 Timoteo", "Tito", "Filemon", "Mga Hebreohanon", "Santiago",
+		"1 Pedro", "2 Pedro", "1 Juan", "2 Juan", "3 Juan",
+		"Judas", "Pinadayag",
+	},
+	// Spanish
+	"es": {
+		"Génesis", "Éxodo", "Levítico", "Números", "Deuteronomio",
+		"Josué", "Jueces", "Rut", "1 Samuel", "2 Samuel",
+		"1 Reyes", "2 Reyes", "1 Crónicas", "2 Crónicas", "Esdras",
+		"Nehemías", "Ester", "Job", "Salmos", "Proverbios",
+		"Eclesiastés", "Cantares", "Isaías", "Jeremías", "Lamentaciones",
+		"Ezequiel", "Daniel", "Oseas", "Joel", "Amós",
+		"Abdías", "Jonás", "Miqueas", "Nahúm", "Habacuc",
+		"Sofonías", "Hageo", "Zacarías", "Malaquías",
+		"Mateo", "Marcos", "Lucas", "Juan", "Hechos",
+		"Romanos", "1 Corintios", "2 Corintios", "Gálatas", "Efesios",
+		"Filipenses", "Colosenses", "1 Tesalonicenses", "2 Tesalonicenses", "1 Timoteo",
+		"2 Timoteo", "Tito", "Filemón", "Hebreos", "Santiago",
+		"1 Pedro", "2 Pedro", "1 Juan", "2 Juan", "3 Juan",
+		"Judas", "Apocalipsis",
+	},
+	// Tagalog
+	"tl": {
+		"Genesis", "Exodo", "Levitico", "Mga Bilang", "Deuteronomio",
+		"Josue", "Mga Hukom", "Ruth", "1 Samuel", "2 Samuel",
+		"1 Mga Hari", "2 Mga Hari", "1 Mga Cronica", "2 Mga Cronica", "Ezra",
+		"Nehemias", "Ester", "Job", "Mga Awit", "Mga Kawikaan",
+		"Eclesiastes", "Awit ng mga Awit", "Isaias", "Jeremias", "Mga Panaghoy",
+		"Ezekiel", "Daniel", "Oseas", "Joel", "Amos",
+		"Obadias", "Jonas", "Mikas", "Nahum", "Habakuk",
+		"Zefanias", "Hagai", "Zacarias", "Malakias",
+		"Mateo", "Marcos", "Lucas", "Juan", "Mga Gawa",
+		"Mga Taga-Roma", "1 Mga Taga-Corinto", "2 Mga Taga-Corinto", "Mga Taga-Galacia", "Mga Taga-Efeso",
+		"Mga Taga-Filipos", "Mga Taga-Colosas", "1 Mga Taga-Tesalonica", "2 Mga Taga-Tesalonica", "1 Timoteo",
+		"2 Timoteo", "Tito", "Filemon", "Mga Hebreo", "Santiago",
+		"1 Pedro", "2 Pedro", "1 Juan", "2 Juan", "3 Juan",
+		"Judas", "Pahayag",
+	},
+}
+
+// ordinalPrefixes maps spelled out and roman numeral book number prefixes to digits
+var ordinalPrefixes = map[string]string{
+	"1st": "1", "first": "1", "i": "1",
+	"2nd": "2", "second": "2", "ii": "2",
+	"3rd": "3", "third": "3", "iii": "3",
+}
+
+// accentReplacer removes the accents common in book names
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// NormalizeBookName normalizes a book name for alias lookups:
+// lowercase without accents, dots or hyphens, single spaces and
+// book numbers as digits ("1st John", "I John" and "1John" all become "1 john")
+func NormalizeBookName(name string) string {
+	name = accentReplacer.Replace(strings.ToLower(name))
+	name = strings.NewReplacer(".", " ", "-", " ", "_", " ").Replace(name)
+
+	fields := strings.Fields(name)
+	if len(fields) > 1 {
+		if digit, ok := ordinalPrefixes[fields[0]]; ok {
+			fields[0] = digit
+		}
+	}
+
+	// Separate a leading number from the name, e.g. "1john", but not an ordinal such as "1st"
+	if len(fields) > 0 {
+		first := fields[0]
+		if _, ordinal := ordinalPrefixes[first]; !ordinal && len(first) > 1 &&
+			unicode.IsDigit(rune(first[0])) && unicode.IsLetter(rune(first[1])) {
+			fields = append([]string{first[:1], first[1:]}, fields[1:]...)
+		}
+	}
+	return strings.Join(fields, " ")
+}
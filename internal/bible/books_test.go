@@ -0,0 +1,33 @@
+package bible
+
+import "testing"
+
+func TestNormalizeBookName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"John", "john"},
+		{"1st John", "1 john"},
+		{"I John", "1 john"},
+		{"First John", "1 john"},
+		{"1John", "1 john"},
+		{"1 John", "1 john"},
+		{"II-Kings", "2 kings"},
+		{"iii john", "3 john"},
+		{"  Jn.  ", "jn"},
+		{"Song  of   Solomon", "song of solomon"},
+		{"Génesis", "genesis"},
+		{"Éxodo", "exodo"},
+		{"I", "i"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeBookName(tt.name); got != tt.want {
+				t.Errorf("NormalizeBookName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
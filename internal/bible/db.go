@@ -6,37 +6,29 @@ import (
 	"path/filepath"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/schema"
 )
 
 // DB is the global database connection
 var DB *sql.DB
 
-// getDBPath returns the path to the SQLite database file
-// It checks for the DB_PATH environment variable and falls back to a default
-func getDBPath() string {
-	dbPath := os.Getenv("DB_PATH")
-	if dbPath == "" {
-		// Allow app to access db on the same dir as the executable
-		// if there is no .env or the DB_PATH varaible is not set
-		dbPath = "./bible.db"
-	}
-	return dbPath
-}
-
 // OpenDB opens a connection to the SQLite database
 // and returns a pointer to the database
 // and an error if it occurs.
 func OpenDB() (*sql.DB, error) {
 	// Get the database path
-	dbPath := getDBPath()
+	dbPath := appdir.DBPath()
 
-	// Ensure the data directory exists
+	// Ensure the database directory exists
 	err := os.MkdirAll(filepath.Dir(dbPath), 0755)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	// Write-ahead logging lets the UI read while translations are imported in
+	// the background, and writers wait for each other instead of failing
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=5000")
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +71,7 @@ func createTables() error {
 			chapter INTEGER NOT NULL,
 			verse INTEGER NOT NULL,
 			text TEXT NOT NULL,
+			markup TEXT NOT NULL DEFAULT '',
 			UNIQUE(translation, book, chapter, verse)
 		)
 	`)
@@ -86,6 +79,12 @@ func createTables() error {
 		return err
 	}
 
+	// Add the verse markup to databases created before it existed
+	err = schema.AddColumn(DB, "bible", "markup", "TEXT NOT NULL DEFAULT ''")
+	if err != nil {
+		return err
+	}
+
 	// Create index for faster lookups
 	_, err = DB.Exec(`
 		CREATE INDEX IF NOT EXISTS idx_bible_lookup 
@@ -119,6 +118,73 @@ func createTables() error {
 		return err
 	}
 
+	// Create the section_headings table for headings before verses, e.g. "The Creation"
+	_, err = DB.Exec(`
+		CREATE TABLE IF NOT EXISTS section_headings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			translation TEXT NOT NULL,
+			book TEXT NOT NULL,
+			chapter INTEGER NOT NULL,
+			verse INTEGER NOT NULL,
+			heading TEXT NOT NULL,
+			UNIQUE(translation, book, chapter, verse)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Create the translations table for per-translation metadata
+	_, err = DB.Exec(`
+		CREATE TABLE IF NOT EXISTS translations (
+			name TEXT PRIMARY KEY,
+			language TEXT NOT NULL DEFAULT 'en',
+			visible INTEGER NOT NULL DEFAULT 1,
+			is_default INTEGER NOT NULL DEFAULT 0,
+			sort_order INTEGER NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Add the display settings to databases created before they existed
+	for _, column := range []struct{ name, definition string }{
+		{"visible", "INTEGER NOT NULL DEFAULT 1"},
+		{"is_default", "INTEGER NOT NULL DEFAULT 0"},
+		{"sort_order", "INTEGER NOT NULL DEFAULT 0"},
+	} {
+		if err := schema.AddColumn(DB, "translations", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	// Create the book_aliases table for localized book names and abbreviations
+	_, err = DB.Exec(`
+		CREATE TABLE IF NOT EXISTS book_aliases (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			language TEXT NOT NULL,
+			book TEXT NOT NULL,
+			alias TEXT NOT NULL,
+			normalized TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			builtin INTEGER NOT NULL DEFAULT 0,
+			UNIQUE(language, normalized)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Create index for alias lookups across languages
+	_, err = DB.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_book_aliases_normalized
+		ON book_aliases(normalized)
+	`)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
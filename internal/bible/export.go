@@ -0,0 +1,298 @@
+package bible
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Translation export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatOSIS = "osis"
+)
+
+// ExportFormats lists the supported translation export formats
+var ExportFormats = []string{ExportFormatJSON, ExportFormatCSV, ExportFormatOSIS}
+
+// exportBook is a book of a translation read for an export
+type exportBook struct {
+	Name     string
+	Chapters []*exportChapter
+}
+
+// exportChapter is a chapter of a translation read for an export
+type exportChapter struct {
+	Number   int
+	Header   string
+	Headings map[int]string // Section headings by the verse they come before
+	Verses   []*Verse
+}
+
+// ExportTranslation writes books of a translation in the given format,
+// or the whole translation when books is empty. Chapter headers, section
+// headings and verse markup are included.
+func ExportTranslation(w io.Writer, format, translation string, books []string) error {
+	data, err := readTranslation(translation, books)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case ExportFormatJSON:
+		return exportJSON(w, translation, data)
+	case ExportFormatCSV:
+		return exportCSV(w, translation, data)
+	case ExportFormatOSIS, "xml":
+		return exportOSIS(w, translation, data)
+	}
+	return fmt.Errorf("unsupported export format: %s", format)
+}
+
+// ParseBookRange parses books of a translation such as "John", "Matthew-John" or
+// "Genesis-Deuteronomy, Psalms" into canonical book names in Bible order.
+// Book names can be in any language or abbreviated. An empty range is the whole translation.
+func ParseBookRange(translation, spec string) ([]string, error) {
+	books, err := GetBooks(translation)
+	if err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("translation not found: %s", translation)
+	}
+	if strings.TrimSpace(spec) == "" {
+		return books, nil
+	}
+
+	language, err := GetTranslationLanguage(translation)
+	if err != nil {
+		return nil, err
+	}
+
+	// index returns the position of a book in the translation
+	index := func(name string) (int, error) {
+		book, ok, err := ResolveBookName(strings.TrimSpace(name), language)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, fmt.Errorf("unknown book: %s", strings.TrimSpace(name))
+		}
+		i := slices.Index(books, book)
+		if i < 0 {
+			return 0, fmt.Errorf("%s is not in %s", book, translation)
+		}
+		return i, nil
+	}
+
+	selected := make([]bool, len(books))
+	for _, part := range strings.Split(spec, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := index(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = index(to); err != nil {
+				return nil, err
+			}
+		}
+		if last < first {
+			return nil, fmt.Errorf("invalid book range: %s", strings.TrimSpace(part))
+		}
+		for i := first; i <= last; i++ {
+			selected[i] = true
+		}
+	}
+
+	var result []string
+	for i, book := range books {
+		if selected[i] {
+			result = append(result, book)
+		}
+	}
+	return result, nil
+}
+
+// readTranslation reads books of a translation in Bible order with their
+// chapter headers and section headings, or all books when books is empty
+func readTranslation(translation string, books []string) ([]*exportBook, error) {
+	if len(books) == 0 {
+		var err error
+		if books, err = GetBooks(translation); err != nil {
+			return nil, err
+		}
+		if len(books) == 0 {
+			return nil, fmt.Errorf("translation not found: %s", translation)
+		}
+	}
+	books = slices.Clone(books)
+	SortBooks(books)
+
+	var data []*exportBook
+	for _, book := range books {
+		chapters, err := GetChapters(translation, book)
+		if err != nil {
+			return nil, err
+		}
+
+		b := &exportBook{Name: book}
+		for _, number := range chapters {
+			verses, err := GetChapterVerses(translation, book, number)
+			if err != nil {
+				return nil, err
+			}
+			header, _, err := GetChapterHeader(translation, book, number)
+			if err != nil {
+				return nil, err
+			}
+			headings, err := GetSectionHeadings(translation, book, number)
+			if err != nil {
+				return nil, err
+			}
+			b.Chapters = append(b.Chapters, &exportChapter{
+				Number:   number,
+				Header:   header,
+				Headings: headings,
+				Verses:   verses,
+			})
+		}
+		data = append(data, b)
+	}
+	return data, nil
+}
+
+// exportJSON writes books in the shape of the Bible JSON files, so they can be loaded again
+func exportJSON(w io.Writer, translation string, books []*exportBook) error {
+	language, err := GetTranslationLanguage(translation)
+	if err != nil {
+		return err
+	}
+
+	bibleData := BibleData{
+		Version:   translation,
+		Language:  language,
+		BookNames: make(map[string]string),
+		Books:     make(map[string]map[string]ChapterData, len(books)),
+	}
+
+	for _, book := range books {
+		name, ok, err := translationBookName(language, book.Name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			bibleData.BookNames[book.Name] = name
+		}
+
+		chapters := make(map[string]ChapterData, len(book.Chapters))
+		for _, chapter := range book.Chapters {
+			chapterData := ChapterData{
+				Header: chapter.Header,
+				Verses: make(map[string]string, len(chapter.Verses)),
+			}
+			for _, verse := range chapter.Verses {
+				key := strconv.Itoa(verse.Verse)
+				chapterData.Verses[key] = verseSource(verse)
+
+				// Headings in the verse text come back from its <h> tag
+				if heading, ok := chapter.Headings[verse.Verse]; ok && !hasHeading(verse.Spans, heading) {
+					if chapterData.Headings == nil {
+						chapterData.Headings = make(map[string]string)
+					}
+					chapterData.Headings[key] = heading
+				}
+			}
+			chapters[strconv.Itoa(chapter.Number)] = chapterData
+		}
+		bibleData.Books[book.Name] = chapters
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false) // Keep markup tags readable
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bibleData)
+}
+
+// exportCSV writes one row per verse. The header is repeated on every verse of its chapter,
+// and the section heading is on the verse it comes before.
+func exportCSV(w io.Writer, translation string, books []*exportBook) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"translation", "book", "chapter", "verse", "header", "heading", "text", "markup"}); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		for _, chapter := range book.Chapters {
+			for _, verse := range chapter.Verses {
+				markup := ""
+				if len(verse.Spans) > 0 {
+					markup = TaggedText(verse.Spans)
+				}
+				record := []string{
+					translation,
+					book.Name,
+					strconv.Itoa(chapter.Number),
+					strconv.Itoa(verse.Verse),
+					chapter.Header,
+					chapter.Headings[verse.Verse],
+					verse.Text,
+					markup,
+				}
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// verseSource returns the text of a verse as written in Bible JSON files, with its markup tags
+func verseSource(verse *Verse) string {
+	if len(verse.Spans) == 0 {
+		return verse.Text
+	}
+	return TaggedText(verse.Spans)
+}
+
+// hasHeading reports whether spans contain the given section heading
+func hasHeading(spans []Span, heading string) bool {
+	for _, span := range spans {
+		if span.Kind == SpanHeading && span.Text == heading {
+			return true
+		}
+	}
+	return false
+}
+
+// translationBookName returns the book name that a translation's JSON file set
+// for a language. Built-in names are left out, so they aren't copied into files.
+func translationBookName(language, book string) (name string, ok bool, err error) {
+	err = DB.QueryRow(`
+		SELECT alias
+		FROM book_aliases
+		WHERE language = ? AND book = ? AND kind = ? AND builtin = 0
+		ORDER BY id
+		LIMIT 1
+	`, language, book, AliasKindName).Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return name, true, nil
+}
@@ -0,0 +1,270 @@
+package bible
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Testaments used to pick books for generators
+const (
+	TestamentOld = "old"
+	TestamentNew = "new"
+)
+
+// oldTestamentBooks is the number of Old Testament books in CanonicalBooks
+const oldTestamentBooks = 39
+
+// Generator picks the next verse to show, e.g. on a lobby screen
+type Generator interface {
+	Next() (*Verse, error)
+}
+
+// WeightedReference is an entry of a curated verse list.
+// Entries with a higher weight are picked more often.
+type WeightedReference struct {
+	Reference string
+	Weight    int
+}
+
+// TestamentBooks returns the canonical books of a testament,
+// or all books for an empty testament
+func TestamentBooks(testament string) ([]string, error) {
+	switch testament {
+	case "":
+		return CanonicalBooks, nil
+	case TestamentOld:
+		return CanonicalBooks[:oldTestamentBooks], nil
+	case TestamentNew:
+		return CanonicalBooks[oldTestamentBooks:], nil
+	default:
+		return nil, fmt.Errorf("unknown testament: %q", testament)
+	}
+}
+
+// RandomVerse picks a verse from the given books using rng.
+// An empty book list picks from the whole translation. Verses are counted in
+// Bible order rather than the order they were imported, so the same rng picks
+// the same verse in every database.
+func RandomVerse(translation string, books []string, rng *rand.Rand) (*Verse, error) {
+	where, args := verseFilter(translation, books)
+
+	rows, err := DB.Query("SELECT book, COUNT(*) FROM bible WHERE "+where+" GROUP BY book", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	var found []string
+	total := 0
+	for rows.Next() {
+		var book string
+		var count int
+		if err := rows.Scan(&book, &count); err != nil {
+			return nil, err
+		}
+		counts[book] = count
+		found = append(found, book)
+		total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no verses found in %s for the selected books", translation)
+	}
+
+	// Find the book of the nth verse, and the verse's place in it
+	SortBooks(found)
+	n := rng.IntN(total)
+	book := found[len(found)-1]
+	for _, b := range found {
+		if n < counts[b] {
+			book = b
+			break
+		}
+		n -= counts[b]
+	}
+
+	query := `
+		SELECT id, translation, book, chapter, verse, text, markup
+		FROM bible
+		WHERE translation = ? AND book = ?
+		ORDER BY chapter, verse
+		LIMIT 1 OFFSET ?
+	`
+	var v Verse
+	if err := scanVerse(DB.QueryRow(query, translation, book, n), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// VerseOfTheDay picks a verse from the given books that stays the same all day
+func VerseOfTheDay(translation string, books []string, date time.Time) (*Verse, error) {
+	return RandomVerse(translation, books, dateRand(date))
+}
+
+// PickWeighted picks an entry of a curated list, favoring higher weights.
+// Entries without a positive weight count as weight 1.
+func PickWeighted(entries []WeightedReference, rng *rand.Rand) (WeightedReference, error) {
+	if len(entries) == 0 {
+		return WeightedReference{}, fmt.Errorf("the verse list is empty")
+	}
+
+	total := 0
+	for _, entry := range entries {
+		total += max(entry.Weight, 1)
+	}
+
+	n := rng.IntN(total)
+	for _, entry := range entries {
+		n -= max(entry.Weight, 1)
+		if n < 0 {
+			return entry, nil
+		}
+	}
+	return entries[len(entries)-1], nil
+}
+
+// ParseWeightedList parses a curated verse list with one reference per line
+// and an optional weight after a "|", e.g. "John 3:16 | 3". Blank lines and
+// lines starting with "#" are skipped.
+func ParseWeightedList(text string) ([]WeightedReference, error) {
+	var entries []WeightedReference
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry := WeightedReference{Reference: line, Weight: 1}
+		if reference, weight, ok := strings.Cut(line, "|"); ok {
+			w, err := strconv.Atoi(strings.TrimSpace(weight))
+			if err != nil || w < 1 {
+				return nil, fmt.Errorf("line %d: invalid weight %q", i+1, strings.TrimSpace(weight))
+			}
+			entry = WeightedReference{Reference: strings.TrimSpace(reference), Weight: w}
+		}
+
+		if _, _, _, err := ParseBibleReference(entry.Reference); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// RandomGenerator picks random verses from a set of books
+type RandomGenerator struct {
+	Translation string
+	Books       []string // Empty for the whole translation
+	rng         *rand.Rand
+}
+
+// NewRandomGenerator creates a random verse generator
+func NewRandomGenerator(translation string, books []string) *RandomGenerator {
+	return &RandomGenerator{
+		Translation: translation,
+		Books:       books,
+		rng:         rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// Next returns a random verse
+func (g *RandomGenerator) Next() (*Verse, error) {
+	return RandomVerse(g.Translation, g.Books, g.rng)
+}
+
+// DailyGenerator returns the verse of the day
+type DailyGenerator struct {
+	Translation string
+	Books       []string // Empty for the whole translation
+}
+
+// NewDailyGenerator creates a verse of the day generator
+func NewDailyGenerator(translation string, books []string) *DailyGenerator {
+	return &DailyGenerator{
+		Translation: translation,
+		Books:       books,
+	}
+}
+
+// Next returns today's verse
+func (g *DailyGenerator) Next() (*Verse, error) {
+	return VerseOfTheDay(g.Translation, g.Books, time.Now())
+}
+
+// ListGenerator goes through a curated verse list, in order or by weight
+type ListGenerator struct {
+	Translation string
+	Entries     []WeightedReference
+	Shuffle     bool // Pick by weight instead of cycling in order
+	next        int
+	last        string
+	rng         *rand.Rand
+}
+
+// NewListGenerator creates a generator for a curated verse list
+func NewListGenerator(translation string, entries []WeightedReference, shuffle bool) *ListGenerator {
+	return &ListGenerator{
+		Translation: translation,
+		Entries:     entries,
+		Shuffle:     shuffle,
+		rng:         rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// Next returns the next verse of the list
+func (g *ListGenerator) Next() (*Verse, error) {
+	if len(g.Entries) == 0 {
+		return nil, fmt.Errorf("the verse list is empty")
+	}
+
+	var entry WeightedReference
+	if g.Shuffle {
+		// Avoid showing the same verse twice in a row
+		for range 5 {
+			picked, err := PickWeighted(g.Entries, g.rng)
+			if err != nil {
+				return nil, err
+			}
+			entry = picked
+			if entry.Reference != g.last {
+				break
+			}
+		}
+	} else {
+		entry = g.Entries[g.next%len(g.Entries)]
+		g.next++
+	}
+	g.last = entry.Reference
+
+	book, chapter, verse, err := ParseBibleReference(entry.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return GetVerse(g.Translation, book, chapter, verse)
+}
+
+// verseFilter builds the WHERE clause selecting verses of a translation in the given books
+func verseFilter(translation string, books []string) (string, []any) {
+	where := "translation = ?"
+	args := []any{translation}
+	if len(books) > 0 {
+		where += " AND book IN (?" + strings.Repeat(", ?", len(books)-1) + ")"
+		for _, book := range books {
+			args = append(args, book)
+		}
+	}
+	return where, args
+}
+
+// dateRand returns a random source seeded by the calendar date
+func dateRand(date time.Time) *rand.Rand {
+	seed := uint64(date.Year())*10000 + uint64(date.Month())*100 + uint64(date.Day())
+	return rand.New(rand.NewPCG(seed, seed))
+}
@@ -0,0 +1,123 @@
+package bible
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// ImportPreview describes a Bible JSON file before it is imported
+type ImportPreview struct {
+	Path        string
+	Translation string // Name of the translation, from the file name
+	Version     string
+	Language    string
+	Report      *Report
+}
+
+// PreviewImport reads and verifies a Bible JSON file and checks that it can be
+// imported as a new translation
+func PreviewImport(path string) (*ImportPreview, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		return nil, fmt.Errorf("%s is not a Bible JSON file", filepath.Base(path))
+	}
+	translation := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := checkNewTranslation(translation); err != nil {
+		return nil, err
+	}
+
+	report, err := VerifyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if report.Verses == 0 {
+		return nil, fmt.Errorf("%s has no verses", filepath.Base(path))
+	}
+
+	// Read the metadata; the books were read by the verifier
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		Version  string `json:"version"`
+		Language string `json:"language"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if info.Language == "" {
+		info.Language = DefaultLanguage
+	}
+
+	return &ImportPreview{
+		Path:        path,
+		Translation: translation,
+		Version:     info.Version,
+		Language:    info.Language,
+		Report:      report,
+	}, nil
+}
+
+// ImportTranslation copies a Bible JSON file into the translations directory and
+// seeds it, so it is loaded like the other translations
+func ImportTranslation(path string, progress func(SeedProgress)) (string, error) {
+	translation := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := checkNewTranslation(translation); err != nil {
+		return "", err
+	}
+
+	slog.Info("Importing translation", logging.Translation(translation), "file", path)
+	dest := TranslationFile(translation)
+	if err := appdir.CopyFile(path, dest); err != nil {
+		return "", fmt.Errorf("failed to copy %s to %s: %w", path, dest, err)
+	}
+
+	seeded, err := SeedBibleData(progress)
+	if err == nil && !slices.Contains(seeded, translation) {
+		err = fmt.Errorf("translation %s was not loaded", translation)
+	}
+	if err != nil {
+		// Don't leave a file that fails on every start
+		os.Remove(dest)
+		return "", err
+	}
+
+	if err := SeedChapterHeaders(); err != nil {
+		return translation, fmt.Errorf("failed to load chapter headers: %w", err)
+	}
+	return translation, nil
+}
+
+// checkNewTranslation returns an error if a translation with the name is loaded
+// or has a data file already
+func checkNewTranslation(translation string) error {
+	if translation == "" {
+		return fmt.Errorf("missing translation name")
+	}
+
+	translations, err := GetAvailableTranslations()
+	if err != nil {
+		return err
+	}
+	if slices.Contains(translations, translation) {
+		return fmt.Errorf("translation %s is already loaded", translation)
+	}
+
+	if _, err := os.Stat(TranslationFile(translation)); err == nil {
+		return fmt.Errorf("%s already exists", TranslationFile(translation))
+	}
+	return nil
+}
+
+// TranslationFile returns the path of the data file of a translation
+func TranslationFile(translation string) string {
+	return filepath.Join(appdir.TranslationsDir(), translation+".json")
+}
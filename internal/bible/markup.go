@@ -0,0 +1,212 @@
+package bible
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Kinds of verse markup spans
+const (
+	SpanText     = ""         // Verse text
+	SpanHeading  = "heading"  // Section heading before the verse text
+	SpanFootnote = "footnote" // Translator's note, not part of the verse text
+	SpanCrossRef = "crossref" // Related references, not part of the verse text
+)
+
+// Span is a run of verse text with the same markup
+type Span struct {
+	Text   string `json:"text"`
+	Kind   string `json:"kind,omitempty"`
+	Red    bool   `json:"red,omitempty"`    // Words of Christ, shown in red letters
+	Italic bool   `json:"italic,omitempty"` // Words supplied by the translators
+}
+
+// markupTags maps the inline tags of Bible JSON files to the markup they set
+var markupTags = map[string]func(*Span){
+	"j":  func(s *Span) { s.Red = true },
+	"wj": func(s *Span) { s.Red = true },
+	"i":  func(s *Span) { s.Italic = true },
+	"h":  func(s *Span) { s.Kind = SpanHeading },
+	"f":  func(s *Span) { s.Kind = SpanFootnote },
+	"x":  func(s *Span) { s.Kind = SpanCrossRef },
+}
+
+// ParseMarkup parses verse text with inline tags into plain text and spans.
+// Supported tags are <J> or <wj> for words of Christ, <i> for supplied words,
+// <h> for a section heading, <f> for a footnote and <x> for cross-references.
+// Tags can be nested, e.g. "<J>I am <i>he</i></J>". Text without tags returns no spans,
+// and unknown tags are kept as text.
+func ParseMarkup(text string) (string, []Span) {
+	if !strings.Contains(text, "<") {
+		return text, nil
+	}
+
+	var (
+		source = text
+		spans  []Span
+		stack  []string // Open tags, innermost last
+		buffer strings.Builder
+		tagged bool
+	)
+
+	// flush ends the current run of text with the markup of the open tags
+	flush := func() {
+		if buffer.Len() == 0 {
+			return
+		}
+		span := Span{Text: buffer.String()}
+		for _, tag := range stack {
+			markupTags[tag](&span)
+		}
+		buffer.Reset()
+
+		// Join verse text with the same markup, e.g. around a stray closing tag.
+		// Headings and notes stay apart so that each one is its own span.
+		if n := len(spans); n > 0 && span.Kind == SpanText {
+			last := spans[n-1]
+			last.Text = span.Text
+			if last == span {
+				spans[n-1].Text += span.Text
+				return
+			}
+		}
+		spans = append(spans, span)
+	}
+
+	for len(text) > 0 {
+		start := strings.IndexByte(text, '<')
+		if start < 0 {
+			buffer.WriteString(text)
+			break
+		}
+		buffer.WriteString(text[:start])
+		text = text[start:]
+
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			buffer.WriteString(text)
+			break
+		}
+		name := strings.ToLower(strings.TrimSpace(text[1:end]))
+		closing := strings.HasPrefix(name, "/")
+		name = strings.TrimPrefix(name, "/")
+
+		if _, ok := markupTags[name]; !ok {
+			buffer.WriteString(text[:end+1])
+			text = text[end+1:]
+			continue
+		}
+		text = text[end+1:]
+		tagged = true
+
+		// Headings and notes are plain text, so formatting inside them is dropped
+		if inNote(stack) && !isNoteTag(name) {
+			continue
+		}
+		flush()
+
+		if !closing {
+			stack = append(stack, name)
+			continue
+		}
+		// Close the innermost matching tag, ignoring stray closing tags
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] == name {
+				stack = append(stack[:i], stack[i+1:]...)
+				break
+			}
+		}
+	}
+	flush()
+
+	if !tagged {
+		return source, nil
+	}
+	return PlainText(spans), spans
+}
+
+// inNote reports whether a heading, footnote or cross-reference tag is open
+func inNote(stack []string) bool {
+	for _, tag := range stack {
+		if isNoteTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNoteTag reports whether a tag starts text that isn't part of the verse
+func isNoteTag(tag string) bool {
+	return tag == "h" || tag == "f" || tag == "x"
+}
+
+// PlainText returns the verse text of spans without headings, notes or markup
+func PlainText(spans []Span) string {
+	var b strings.Builder
+	for _, span := range spans {
+		if span.Kind == SpanText {
+			b.WriteString(span.Text)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Footnotes returns the footnotes and cross-references of spans
+func Footnotes(spans []Span) []Span {
+	var notes []Span
+	for _, span := range spans {
+		if span.Kind == SpanFootnote || span.Kind == SpanCrossRef {
+			notes = append(notes, span)
+		}
+	}
+	return notes
+}
+
+// encodeMarkup encodes spans for the markup column, empty without spans
+func encodeMarkup(spans []Span) (string, error) {
+	if len(spans) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(spans)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeMarkup decodes the markup column
+func decodeMarkup(markup string) ([]Span, error) {
+	if markup == "" {
+		return nil, nil
+	}
+	var spans []Span
+	if err := json.Unmarshal([]byte(markup), &spans); err != nil {
+		return nil, err
+	}
+	return spans, nil
+}
+
+// TaggedText writes spans back as verse text with inline tags, the reverse of ParseMarkup
+func TaggedText(spans []Span) string {
+	var b strings.Builder
+	for _, span := range spans {
+		switch span.Kind {
+		case SpanHeading:
+			b.WriteString("<h>" + span.Text + "</h>")
+		case SpanFootnote:
+			b.WriteString("<f>" + span.Text + "</f>")
+		case SpanCrossRef:
+			b.WriteString("<x>" + span.Text + "</x>")
+		default:
+			text := span.Text
+			if span.Italic {
+				text = "<i>" + text + "</i>"
+			}
+			if span.Red {
+				text = "<J>" + text + "</J>"
+			}
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
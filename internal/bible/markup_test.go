@@ -0,0 +1,119 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		plain string
+		spans []Span
+	}{
+		{
+			name:  "plain text",
+			text:  "In the beginning was the Word",
+			plain: "In the beginning was the Word",
+		},
+		{
+			name:  "words of Christ",
+			text:  "<J>I am the way</J>, said Jesus",
+			plain: "I am the way, said Jesus",
+			spans: []Span{{Text: "I am the way", Red: true}, {Text: ", said Jesus"}},
+		},
+		{
+			name:  "wj tag",
+			text:  "<wj>Follow me</wj>",
+			plain: "Follow me",
+			spans: []Span{{Text: "Follow me", Red: true}},
+		},
+		{
+			name:  "nested tags",
+			text:  "<J>I am <i>he</i></J>",
+			plain: "I am he",
+			spans: []Span{{Text: "I am ", Red: true}, {Text: "he", Red: true, Italic: true}},
+		},
+		{
+			name:  "heading",
+			text:  "<h>The Word Became Flesh</h>In the beginning",
+			plain: "In the beginning",
+			spans: []Span{{Text: "The Word Became Flesh", Kind: SpanHeading}, {Text: "In the beginning"}},
+		},
+		{
+			name:  "footnote and cross-reference",
+			text:  "loved<f>Or so loved</f> the world<x>Rom 5:8</x>",
+			plain: "loved the world",
+			spans: []Span{
+				{Text: "loved"},
+				{Text: "Or so loved", Kind: SpanFootnote},
+				{Text: " the world"},
+				{Text: "Rom 5:8", Kind: SpanCrossRef},
+			},
+		},
+		{
+			name:  "formatting inside a note is dropped",
+			text:  "word<f>see <i>this</i></f>",
+			plain: "word",
+			spans: []Span{{Text: "word"}, {Text: "see this", Kind: SpanFootnote}},
+		},
+		{
+			name:  "tags are case insensitive",
+			text:  "<I>supplied</I>",
+			plain: "supplied",
+			spans: []Span{{Text: "supplied", Italic: true}},
+		},
+		{
+			name:  "stray closing tag",
+			text:  "grace</i> and peace",
+			plain: "grace and peace",
+			spans: []Span{{Text: "grace and peace"}},
+		},
+		{
+			name:  "unknown tags are kept as text",
+			text:  "a <b>bold</b> word",
+			plain: "a <b>bold</b> word",
+		},
+		{
+			name:  "unclosed angle bracket",
+			text:  "1 < 2",
+			plain: "1 < 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, spans := ParseMarkup(tt.text)
+			if plain != tt.plain {
+				t.Errorf("plain text = %q, want %q", plain, tt.plain)
+			}
+			if !reflect.DeepEqual(spans, tt.spans) {
+				t.Errorf("spans = %+v, want %+v", spans, tt.spans)
+			}
+		})
+	}
+}
+
+func TestTaggedTextRoundTrip(t *testing.T) {
+	tests := []string{
+		"<J>I am the way</J>, said Jesus",
+		"<J>I am <i>he</i></J>",
+		"<h>The Word Became Flesh</h>In the beginning",
+		"loved<f>Or so loved</f> the world<x>Rom 5:8</x>",
+		"the <i>only</i> <J>Son</J>",
+		"<i><J>red and italic</J></i>",
+	}
+
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			plain, spans := ParseMarkup(text)
+			tagged := TaggedText(spans)
+			gotPlain, gotSpans := ParseMarkup(tagged)
+			if gotPlain != plain || !reflect.DeepEqual(gotSpans, spans) {
+				t.Errorf("ParseMarkup(TaggedText(spans)) = %q, %+v; want %q, %+v (tagged %q)",
+					gotPlain, gotSpans, plain, spans, tagged)
+			}
+		})
+	}
+}
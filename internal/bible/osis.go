@@ -0,0 +1,112 @@
+package bible
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/xmltext"
+)
+
+// osisBooks maps canonical book names to OSIS book IDs
+var osisBooks = map[string]string{
+	"Genesis": "Gen", "Exodus": "Exod", "Leviticus": "Lev", "Numbers": "Num", "Deuteronomy": "Deut",
+	"Joshua": "Josh", "Judges": "Judg", "Ruth": "Ruth", "1st Samuel": "1Sam", "2nd Samuel": "2Sam",
+	"1st Kings": "1Kgs", "2nd Kings": "2Kgs", "1st Chronicles": "1Chr", "2nd Chronicles": "2Chr", "Ezra": "Ezra",
+	"Nehemiah": "Neh", "Esther": "Esth", "Job": "Job", "Psalms": "Ps", "Proverbs": "Prov",
+	"Ecclesiastes": "Eccl", "Song of Solomon": "Song", "Isaiah": "Isa", "Jeremiah": "Jer", "Lamentations": "Lam",
+	"Ezekiel": "Ezek", "Daniel": "Dan", "Hosea": "Hos", "Joel": "Joel", "Amos": "Amos",
+	"Obadiah": "Obad", "Jonah": "Jonah", "Micah": "Mic", "Nahum": "Nah", "Habakkuk": "Hab",
+	"Zephaniah": "Zeph", "Haggai": "Hag", "Zechariah": "Zech", "Malachi": "Mal",
+	"Matthew": "Matt", "Mark": "Mark", "Luke": "Luke", "John": "John", "Acts": "Acts",
+	"Romans": "Rom", "1st Corinthians": "1Cor", "2nd Corinthians": "2Cor", "Galatians": "Gal", "Ephesians": "Eph",
+	"Philippians": "Phil", "Colossians": "Col", "1st Thessalonians": "1Thess", "2nd Thessalonians": "2Thess", "1st Timothy": "1Tim",
+	"2nd Timothy": "2Tim", "Titus": "Titus", "Philemon": "Phlm", "Hebrews": "Heb", "James": "Jas",
+	"1st Peter": "1Pet", "2nd Peter": "2Pet", "1st John": "1John", "2nd John": "2John", "3rd John": "3John",
+	"Jude": "Jude", "Revelation": "Rev",
+}
+
+// osisBookID returns the OSIS ID of a book, or its name without spaces for books OSIS doesn't know
+func osisBookID(book string) string {
+	if id, ok := osisBooks[book]; ok {
+		return id
+	}
+	return strings.ReplaceAll(book, " ", "")
+}
+
+// exportOSIS writes books as an OSIS XML document. Words of Christ become <q who="Jesus">,
+// supplied words <transChange type="added">, and footnotes and cross-references <note>.
+func exportOSIS(w io.Writer, translation string, books []*exportBook) error {
+	language, err := GetTranslationLanguage(translation)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s<osis xmlns=\"http://www.bibletechnologies.net/2003/OSIS/namespace\">\n", xml.Header)
+	fmt.Fprintf(bw, "<osisText osisIDWork=\"%s\" osisRefWork=\"Bible\" xml:lang=\"%s\">\n", xmltext.Escape(translation), xmltext.Escape(language))
+	fmt.Fprintf(bw, "<header><work osisWork=\"%[1]s\"><title>%[1]s</title><type type=\"OSIS\">Bible</type>"+
+		"<identifier type=\"OSIS\">Bible.%[1]s</identifier><language>%[2]s</language><refSystem>Bible</refSystem></work></header>\n",
+		xmltext.Escape(translation), xmltext.Escape(language))
+
+	for _, book := range books {
+		id := osisBookID(book.Name)
+		name, err := GetLocalizedBookName(translation, book.Name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "<div type=\"book\" osisID=\"%s\"><title type=\"main\">%s</title>\n", xmltext.Escape(id), xmltext.Escape(name))
+
+		for _, chapter := range book.Chapters {
+			fmt.Fprintf(bw, "<chapter osisID=\"%s.%d\">", xmltext.Escape(id), chapter.Number)
+			if chapter.Header != "" {
+				fmt.Fprintf(bw, "<title type=\"chapter\">%s</title>", xmltext.Escape(chapter.Header))
+			}
+			bw.WriteString("\n")
+
+			for _, verse := range chapter.Verses {
+				if heading, ok := chapter.Headings[verse.Verse]; ok {
+					fmt.Fprintf(bw, "<title>%s</title>\n", xmltext.Escape(heading))
+				}
+				fmt.Fprintf(bw, "<verse osisID=\"%s.%d.%d\">%s</verse>\n", xmltext.Escape(id), chapter.Number, verse.Verse, osisVerseText(verse))
+			}
+			bw.WriteString("</chapter>\n")
+		}
+		bw.WriteString("</div>\n")
+	}
+
+	bw.WriteString("</osisText>\n</osis>\n")
+	return bw.Flush()
+}
+
+// osisVerseText returns the OSIS markup of a verse's text. Headings are left
+// out because they are written as titles before the verse.
+func osisVerseText(verse *Verse) string {
+	if len(verse.Spans) == 0 {
+		return xmltext.Escape(strings.TrimSpace(verse.Text))
+	}
+
+	var b strings.Builder
+	for _, span := range verse.Spans {
+		text := xmltext.Escape(span.Text)
+		switch span.Kind {
+		case SpanHeading:
+			continue
+		case SpanFootnote:
+			text = "<note>" + text + "</note>"
+		case SpanCrossRef:
+			text = "<note type=\"crossReference\">" + text + "</note>"
+		default:
+			if span.Italic {
+				text = "<transChange type=\"added\">" + text + "</transChange>"
+			}
+			if span.Red {
+				text = "<q who=\"Jesus\" marker=\"\">" + text + "</q>"
+			}
+		}
+		b.WriteString(text)
+	}
+	return strings.TrimSpace(b.String())
+}
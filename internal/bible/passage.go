@@ -0,0 +1,73 @@
+package bible
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePassage parses a reference to a verse or a range of verses in one chapter,
+// e.g. "John 3:16" or "John 3:16-18", and returns the canonical book, the chapter
+// and the first and last verse
+func ParsePassage(reference string) (book string, chapter, first, last int, err error) {
+	return ParsePassageIn(reference, "")
+}
+
+// ParsePassageIn parses a passage like ParsePassage. A book name used by
+// several languages is resolved in language.
+func ParsePassageIn(reference, language string) (book string, chapter, first, last int, err error) {
+	reference = strings.TrimSpace(reference)
+	verses := reference
+	if i := strings.LastIndex(reference, ":"); i >= 0 {
+		verses = reference[i+1:]
+	}
+
+	start, end, isRange := strings.Cut(verses, "-")
+	book, chapter, first, err = ParseBibleReferenceIn(strings.TrimSuffix(reference, verses)+strings.TrimSpace(start), language)
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	if !isRange {
+		return book, chapter, first, first, nil
+	}
+
+	last, err = parseIntWithError(strings.TrimSpace(end), "verse")
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	if last < first {
+		return "", 0, 0, 0, fmt.Errorf("invalid verse range: %s", verses)
+	}
+	return book, chapter, first, last, nil
+}
+
+// GetPassage retrieves the verses from first to last of a chapter in order
+func GetPassage(translation, book string, chapter, first, last int) ([]*Verse, error) {
+	query := `
+		SELECT id, translation, book, chapter, verse, text, markup
+		FROM bible
+		WHERE translation = ? AND book = ? AND chapter = ? AND verse BETWEEN ? AND ?
+		ORDER BY verse ASC
+	`
+	rows, err := DB.Query(query, translation, book, chapter, first, last)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verses []*Verse
+	for rows.Next() {
+		var v Verse
+		if err := scanVerse(rows, &v); err != nil {
+			return nil, err
+		}
+		verses = append(verses, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(verses) == 0 {
+		return nil, fmt.Errorf("passage not found: %s %s %d:%d-%d", translation, book, chapter, first, last)
+	}
+	return verses, nil
+}
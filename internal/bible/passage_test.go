@@ -0,0 +1,111 @@
+package bible
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+)
+
+// TestMain opens a database with the built-in book aliases, which references are resolved with
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "mr-verse-bible")
+	if err != nil {
+		panic(err)
+	}
+	appdir.SetOverrides(appdir.Overrides{DB: filepath.Join(dir, "bible.db")})
+	if err := InitDB(); err != nil {
+		panic(err)
+	}
+	if err := SeedBookAliases(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	CloseDB()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestParsePassage(t *testing.T) {
+	tests := []struct {
+		reference   string
+		book        string
+		chapter     int
+		first, last int
+		wantErr     bool
+	}{
+		{reference: "John 3:16", book: "John", chapter: 3, first: 16, last: 16},
+		{reference: "John 3:16-18", book: "John", chapter: 3, first: 16, last: 18},
+		{reference: "  John 3:16 - 18 ", book: "John", chapter: 3, first: 16, last: 18},
+		{reference: "Jn 3:16", book: "John", chapter: 3, first: 16, last: 16},
+		{reference: "1 Cor 13:4-7", book: "1st Corinthians", chapter: 13, first: 4, last: 7},
+		{reference: "1st John 1:9", book: "1st John", chapter: 1, first: 9, last: 9},
+		{reference: "Song of Solomon 2:4", book: "Song of Solomon", chapter: 2, first: 4, last: 4},
+		{reference: "Juan 3:16", book: "John", chapter: 3, first: 16, last: 16},
+		{reference: "Psalm 23:1-6", book: "Psalms", chapter: 23, first: 1, last: 6},
+		{reference: "John 3:18-16", wantErr: true},
+		{reference: "John 3:16-x", wantErr: true},
+		{reference: "John 3", wantErr: true},
+		{reference: "John", wantErr: true},
+		{reference: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			book, chapter, first, last, err := ParsePassage(tt.reference)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePassage(%q) = %s %d:%d-%d, want an error", tt.reference, book, chapter, first, last)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePassage(%q): %v", tt.reference, err)
+			}
+			if book != tt.book || chapter != tt.chapter || first != tt.first || last != tt.last {
+				t.Errorf("ParsePassage(%q) = %s %d:%d-%d, want %s %d:%d-%d", tt.reference,
+					book, chapter, first, last, tt.book, tt.chapter, tt.first, tt.last)
+			}
+		})
+	}
+}
+
+func TestParseBibleReferenceIn(t *testing.T) {
+	// Aliases shared by languages, one of them also an English abbreviation
+	for _, alias := range []BookAlias{
+		{Language: "tl", Book: "Acts", Alias: "Gaw", Kind: AliasKindAlias},
+		{Language: "ceb", Book: "Galatians", Alias: "Gaw", Kind: AliasKindAlias},
+		{Language: "tl", Book: "Philemon", Alias: "Phil", Kind: AliasKindAlias},
+	} {
+		if err := AddBookAlias(alias); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		reference string
+		language  string
+		book      string
+	}{
+		{reference: "Gaw 2:1", language: "tl", book: "Acts"},
+		{reference: "Gaw 2:1", language: "ceb", book: "Galatians"},
+		{reference: "Phil 2:1", language: "tl", book: "Philemon"},
+		{reference: "Phil 2:1", language: "ceb", book: "Philippians"},
+		{reference: "Phil 2:1", language: "", book: "Philippians"},
+		{reference: "Jn 2:1", language: "tl", book: "John"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference+" in "+tt.language, func(t *testing.T) {
+			book, chapter, verse, err := ParseBibleReferenceIn(tt.reference, tt.language)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if book != tt.book || chapter != 2 || verse != 1 {
+				t.Errorf("ParseBibleReferenceIn(%q, %q) = %s %d:%d, want %s 2:1", tt.reference, tt.language, book, chapter, verse, tt.book)
+			}
+		})
+	}
+}
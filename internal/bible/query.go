@@ -1,13 +1,20 @@
 package bible
 
 import (
+	"cmp"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/logging"
 )
 
 // Verse represents a single Bible verse
@@ -18,18 +25,37 @@ type Verse struct {
 	Chapter     int    `json:"chapter"`
 	Verse       int    `json:"verse"`
 	Text        string `json:"text"`
+	Spans       []Span `json:"spans,omitempty"` // Markup of the text, empty for plain text
+}
+
+// scanVerse scans a row of verse columns including the markup into v
+func scanVerse(row interface{ Scan(...any) error }, v *Verse) error {
+	var markup string
+	if err := row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text, &markup); err != nil {
+		return err
+	}
+
+	spans, err := decodeMarkup(markup)
+	if err != nil {
+		return fmt.Errorf("invalid markup for %s %d:%d: %w", v.Book, v.Chapter, v.Verse, err)
+	}
+	v.Spans = spans
+	return nil
 }
 
 // BibleData represents the structure of the Bible JSON files
 type BibleData struct {
-	Version string                            `json:"version"`
-	Books   map[string]map[string]ChapterData `json:"books"`
+	Version   string                            `json:"version"`
+	Language  string                            `json:"language,omitempty"`   // Language code, e.g. "en" or "ceb"
+	BookNames map[string]string                 `json:"book_names,omitempty"` // Canonical book name to localized name
+	Books     map[string]map[string]ChapterData `json:"books"`
 }
 
 // ChapterData represents the structure of a chapter in the Bible JSON files
 type ChapterData struct {
-	Header string            `json:"header"`
-	Verses map[string]string `json:"verses"`
+	Header   string            `json:"header"`
+	Verses   map[string]string `json:"verses"`
+	Headings map[string]string `json:"headings,omitempty"` // Section headings by the verse they come before
 }
 
 // GetChapterHeader fetches a localized chapter header for a translation/book/chapter.
@@ -73,17 +99,128 @@ func GetAvailableTranslations() ([]string, error) {
 	return translations, nil
 }
 
+// GetBooks returns the books of a translation in Bible order.
+// Books that are not in CanonicalBooks come last in alphabetical order.
+func GetBooks(translation string) ([]string, error) {
+	rows, err := DB.Query("SELECT DISTINCT book FROM bible WHERE translation = ?", translation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []string
+	for rows.Next() {
+		var book string
+		if err := rows.Scan(&book); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	SortBooks(books)
+	return books, nil
+}
+
+// SortBooks sorts book names in Bible order
+func SortBooks(books []string) {
+	sort.SliceStable(books, func(i, j int) bool {
+		return CompareBooks(books[i], books[j]) < 0
+	})
+}
+
+// bookOrder maps the canonical books to their place in the Bible
+var bookOrder = func() map[string]int {
+	order := make(map[string]int, len(CanonicalBooks))
+	for i, book := range CanonicalBooks {
+		order[book] = i
+	}
+	return order
+}()
+
+// CompareBooks compares book names in Bible order, returning -1, 0 or +1.
+// Books that are not in CanonicalBooks come last in alphabetical order.
+func CompareBooks(a, b string) int {
+	oa, aKnown := bookOrder[a]
+	ob, bKnown := bookOrder[b]
+	switch {
+	case aKnown && bKnown:
+		return cmp.Compare(oa, ob)
+	case aKnown != bKnown:
+		if aKnown {
+			return -1
+		}
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// GetChapters returns the chapter numbers of a book in a translation
+func GetChapters(translation, book string) ([]int, error) {
+	query := `
+		SELECT DISTINCT chapter
+		FROM bible
+		WHERE translation = ? AND book = ?
+		ORDER BY chapter ASC
+	`
+	rows, err := DB.Query(query, translation, book)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chapters []int
+	for rows.Next() {
+		var chapter int
+		if err := rows.Scan(&chapter); err != nil {
+			return nil, err
+		}
+		chapters = append(chapters, chapter)
+	}
+
+	return chapters, rows.Err()
+}
+
+// GetChapterVerses returns the verses of a chapter in order
+func GetChapterVerses(translation, book string, chapter int) ([]*Verse, error) {
+	query := `
+		SELECT id, translation, book, chapter, verse, text, markup
+		FROM bible
+		WHERE translation = ? AND book = ? AND chapter = ?
+		ORDER BY verse ASC
+	`
+	rows, err := DB.Query(query, translation, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verses []*Verse
+	for rows.Next() {
+		var v Verse
+		if err := scanVerse(rows, &v); err != nil {
+			return nil, err
+		}
+		verses = append(verses, &v)
+	}
+
+	return verses, rows.Err()
+}
+
 // GetVerse retrieves a specific verse from the database
 func GetVerse(translation, book string, chapter, verse int) (*Verse, error) {
 	query := `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book = ? AND chapter = ? AND verse = ?
 	`
 	row := DB.QueryRow(query, translation, book, chapter, verse)
 
 	var v Verse
-	err := row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err := scanVerse(row, &v)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf(
@@ -104,7 +241,7 @@ func GetVerse(translation, book string, chapter, verse int) (*Verse, error) {
 func GetNextVerse(translation, book string, chapter, verse int) (*Verse, error) {
 	// First try to get the next verse in the same chapter
 	query := `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book = ? AND chapter = ? AND verse > ?
 		ORDER BY verse ASC
@@ -113,7 +250,7 @@ func GetNextVerse(translation, book string, chapter, verse int) (*Verse, error)
 	row := DB.QueryRow(query, translation, book, chapter, verse)
 
 	var v Verse
-	err := row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err := scanVerse(row, &v)
 	if err == nil {
 		return &v, nil
 	}
@@ -124,14 +261,14 @@ func GetNextVerse(translation, book string, chapter, verse int) (*Verse, error)
 
 	// If no next verse in the same chapter, try to get the first verse of the next chapter
 	query = `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book = ? AND chapter > ?
 		ORDER BY chapter ASC, verse ASC
 		LIMIT 1
 	`
 	row = DB.QueryRow(query, translation, book, chapter)
-	err = row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err = scanVerse(row, &v)
 	if err == nil {
 		return &v, nil
 	}
@@ -142,14 +279,14 @@ func GetNextVerse(translation, book string, chapter, verse int) (*Verse, error)
 
 	// If no next chapter in the same book, try to get the first verse of the next book
 	query = `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book > ?
 		ORDER BY book ASC, chapter ASC, verse ASC
 		LIMIT 1
 	`
 	row = DB.QueryRow(query, translation, book)
-	err = row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err = scanVerse(row, &v)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf(
@@ -170,7 +307,7 @@ func GetNextVerse(translation, book string, chapter, verse int) (*Verse, error)
 func GetPreviousVerse(translation, book string, chapter, verse int) (*Verse, error) {
 	// First try to get the previous verse in the same chapter
 	query := `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book = ? AND chapter = ? AND verse < ?
 		ORDER BY verse DESC
@@ -179,7 +316,7 @@ func GetPreviousVerse(translation, book string, chapter, verse int) (*Verse, err
 	row := DB.QueryRow(query, translation, book, chapter, verse)
 
 	var v Verse
-	err := row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err := scanVerse(row, &v)
 	if err == nil {
 		return &v, nil
 	}
@@ -190,14 +327,14 @@ func GetPreviousVerse(translation, book string, chapter, verse int) (*Verse, err
 
 	// If no previous verse in the same chapter, try to get the last verse of the previous chapter
 	query = `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book = ? AND chapter < ?
 		ORDER BY chapter DESC, verse DESC
 		LIMIT 1
 	`
 	row = DB.QueryRow(query, translation, book, chapter)
-	err = row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err = scanVerse(row, &v)
 	if err == nil {
 		return &v, nil
 	}
@@ -208,14 +345,14 @@ func GetPreviousVerse(translation, book string, chapter, verse int) (*Verse, err
 
 	// If no previous chapter in the same book, try to get the last verse of the previous book
 	query = `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND book < ?
 		ORDER BY book DESC, chapter DESC, verse DESC
 		LIMIT 1
 	`
 	row = DB.QueryRow(query, translation, book)
-	err = row.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text)
+	err = scanVerse(row, &v)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf(
@@ -235,7 +372,7 @@ func GetPreviousVerse(translation, book string, chapter, verse int) (*Verse, err
 // SearchVerses searches for verses containing the given text
 func SearchVerses(translation, searchText string) ([]*Verse, error) {
 	query := `
-		SELECT id, translation, book, chapter, verse, text
+		SELECT id, translation, book, chapter, verse, text, markup
 		FROM bible
 		WHERE translation = ? AND (book LIKE ? OR text LIKE ?)
 		ORDER BY book, chapter, verse
@@ -251,7 +388,7 @@ func SearchVerses(translation, searchText string) ([]*Verse, error) {
 	var verses []*Verse
 	for rows.Next() {
 		var v Verse
-		if err := rows.Scan(&v.ID, &v.Translation, &v.Book, &v.Chapter, &v.Verse, &v.Text); err != nil {
+		if err := scanVerse(rows, &v); err != nil {
 			return nil, err
 		}
 		verses = append(verses, &v)
@@ -261,16 +398,28 @@ func SearchVerses(translation, searchText string) ([]*Verse, error) {
 }
 
 // ParseBibleReference parses a Bible reference string (e.g., "John 3:16")
-// and returns the book, chapter, and verse
+// and returns the canonical book, chapter, and verse.
+// The book may be given in any language or abbreviated (e.g., "Juan 3:16", "Jn 3:16").
 func ParseBibleReference(reference string) (string, int, int, error) {
-	parts := strings.Split(reference, " ")
+	return ParseBibleReferenceIn(reference, "")
+}
+
+// ParseBibleReferenceIn parses a Bible reference like ParseBibleReference.
+// A book name used by several languages is resolved in language,
+// e.g. the language of the translation being shown.
+func ParseBibleReferenceIn(reference, language string) (string, int, int, error) {
+	parts := strings.Fields(reference)
 	if len(parts) < 2 {
 		return "", 0, 0, fmt.Errorf("invalid Bible reference format: %s", reference)
 	}
 
 	book := strings.Join(parts[:len(parts)-1], " ")
 
-	if strings.Contains(book, "1") && !strings.Contains(book, "1st") {
+	if resolved, ok, err := ResolveBookName(book, language); err != nil {
+		return "", 0, 0, err
+	} else if ok {
+		book = resolved
+	} else if strings.Contains(book, "1") && !strings.Contains(book, "1st") {
 		book = strings.Replace(book, "1", "1st", 1)
 	} else if strings.Contains(book, "2") && !strings.Contains(book, "2nd") {
 		book = strings.Replace(book, "2", "2nd", 1)
@@ -308,14 +457,44 @@ func parseIntWithError(s, field string) (int, error) {
 	return result, nil
 }
 
-// SeedBibleData loads Bible data from JSON files in the data directory
-func SeedBibleData() error {
-	// Get all JSON files in the data directory
-	files, err := filepath.Glob("./data/*.json")
+// SeedProgress is reported while Bible data files are loaded
+type SeedProgress struct {
+	File        string // Data file being loaded
+	Translation string
+	FileIndex   int // 1-based position of the file among the files to load
+	FileCount   int
+	Book        string // Book just inserted, empty when the file starts
+	BookIndex   int
+	BookCount   int
+	Rows        int  // Verses of the file inserted so far
+	Done        bool // All files are loaded
+}
+
+// seedMutex keeps translations from being seeded twice when an import
+// starts while the translations directory is being seeded
+var seedMutex sync.Mutex
+
+// SeedBibleData loads Bible data from JSON files in the translations directory
+// and returns the translations it loaded. Progress is reported per file
+// and per book when progress isn't nil.
+func SeedBibleData(progress func(SeedProgress)) (seeded []string, err error) {
+	seedMutex.Lock()
+	defer seedMutex.Unlock()
+
+	// Get all JSON files in the translations directory
+	files, err := filepath.Glob(filepath.Join(appdir.TranslationsDir(), "*.json"))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	report := func(p SeedProgress) {
+		if progress != nil {
+			progress(p)
+		}
 	}
 
+	// Find the files that aren't seeded yet first, so progress can count them
+	var pending []string
 	for _, file := range files {
 		// Extract translation name from filename
 		translation := strings.TrimSuffix(filepath.Base(file), ".json")
@@ -325,28 +504,47 @@ func SeedBibleData() error {
 		err := DB.QueryRow("SELECT COUNT(*) FROM bible WHERE translation = ? LIMIT 1", translation).
 			Scan(&count)
 		if err != nil {
-			return err
+			return seeded, err
 		}
 
 		// Skip if already seeded
 		if count > 0 {
-			log.Printf("Translation %s already seeded, skipping...\n", translation)
+			slog.Debug("Translation already seeded, skipping", logging.Translation(translation))
 			continue
 		}
+		pending = append(pending, file)
+	}
+	defer report(SeedProgress{FileIndex: len(pending), FileCount: len(pending), Done: true})
+
+	for i, file := range pending {
+		translation := strings.TrimSuffix(filepath.Base(file), ".json")
+		fileProgress := SeedProgress{File: file, Translation: translation, FileIndex: i + 1, FileCount: len(pending)}
+		report(fileProgress)
 
 		// Load and parse the JSON file
-		log.Printf("Seeding %s translation from %s...\n", translation, file)
-		err = loadBibleFile(file, translation)
+		slog.Info("Seeding translation", logging.Translation(translation), "file", file)
+		start := time.Now()
+		verses := 0
+		err = loadBibleFile(file, translation, func(book string, index, count, rows int) {
+			p := fileProgress
+			p.Book, p.BookIndex, p.BookCount, p.Rows = book, index, count, rows
+			verses = rows
+			report(p)
+		})
 		if err != nil {
-			return fmt.Errorf("error loading %s: %w", file, err)
+			slog.Error("Failed to seed translation", logging.Translation(translation), "file", file, "error", err)
+			return seeded, fmt.Errorf("error loading %s: %w", file, err)
 		}
+		slog.Info("Seeded translation", logging.Translation(translation), "verses", verses, "duration", time.Since(start))
+		seeded = append(seeded, translation)
 	}
 
-	return nil
+	return seeded, nil
 }
 
-// loadBibleFile loads a single Bible JSON file into the database
-func loadBibleFile(filePath, translation string) error {
+// loadBibleFile loads a single Bible JSON file into the database in one transaction,
+// calling bookDone with the running count of verses after each book
+func loadBibleFile(filePath, translation string, bookDone func(book string, index, count, rows int)) (err error) {
 	// Read the file
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -373,16 +571,24 @@ func loadBibleFile(filePath, translation string) error {
 
 	// Prepare the insert statement
 	stmt, err := tx.Prepare(`
-		INSERT INTO bible (translation, book, chapter, verse, text)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO bible (translation, book, chapter, verse, text, markup)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	// Insert each verse
-	for book, chapters := range bibleData.Books {
+	// Insert each verse, book by book in Bible order
+	books := make([]string, 0, len(bibleData.Books))
+	for book := range bibleData.Books {
+		books = append(books, book)
+	}
+	SortBooks(books)
+
+	rows := 0
+	for i, book := range books {
+		chapters := bibleData.Books[book]
 		for chapterStr, chapterData := range chapters {
 			// Parse chapter number
 			chapter, err := parseIntWithError(chapterStr, "chapter")
@@ -398,23 +604,41 @@ func loadBibleFile(filePath, translation string) error {
 					continue // Skip invalid entries
 				}
 
+				// Keep the plain text for search and the markup alongside it
+				plain, spans := ParseMarkup(text)
+				markup, err := encodeMarkup(spans)
+				if err != nil {
+					return err
+				}
+
 				// Insert the verse
-				_, err = stmt.Exec(translation, book, chapter, verse, text)
+				_, err = stmt.Exec(translation, book, chapter, verse, plain, markup)
 				if err != nil {
 					return err
 				}
+				rows++
 			}
 		}
+		bookDone(book, i+1, len(books), rows)
 	}
 
 	// Commit the transaction
-	return tx.Commit()
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	dropBookCache()
+	return nil
 }
 
-// SeedChapterHeaders reads JSON files and stores per-chapter headers.
+// SeedChapterHeaders reads JSON files and stores per-chapter headers and
+// section headings, along with each translation's language and localized book names.
 // Safe to run multiple times thanks to INSERT OR IGNORE.
 func SeedChapterHeaders() error {
-	files, err := filepath.Glob("./data/*.json")
+	seedMutex.Lock()
+	defer seedMutex.Unlock()
+	defer dropBookCache() // Book names from the files are aliases
+
+	files, err := filepath.Glob(filepath.Join(appdir.TranslationsDir(), "*.json"))
 	if err != nil {
 		return err
 	}
@@ -453,15 +677,26 @@ func SeedChapterHeaders() error {
 		}
 		defer stmt.Close()
 
+		if infoErr := saveTranslationInfo(tx, translation, &bibleData); infoErr != nil {
+			txErr = infoErr
+			tx.Rollback()
+			return infoErr
+		}
+
 		for book, chapters := range bibleData.Books {
 			for chapterStr, chapterData := range chapters {
-				if chapterData.Header == "" {
-					continue
-				}
 				chapterNum, parseErr := parseIntWithError(chapterStr, "chapter")
 				if parseErr != nil {
 					continue
 				}
+				if headingErr := saveSectionHeadings(tx, translation, book, chapterNum, chapterData); headingErr != nil {
+					txErr = headingErr
+					tx.Rollback()
+					return headingErr
+				}
+				if chapterData.Header == "" {
+					continue
+				}
 				if _, execErr := stmt.Exec(translation, book, chapterNum, chapterData.Header); execErr != nil {
 					txErr = execErr
 					tx.Rollback()
@@ -473,7 +708,7 @@ func SeedChapterHeaders() error {
 		if commitErr := tx.Commit(); commitErr != nil {
 			return commitErr
 		}
-		log.Printf("Seeded headers for %s from %s...\n", translation, file)
+		slog.Debug("Seeded chapter headers", logging.Translation(translation), "file", file)
 	}
 
 	return nil
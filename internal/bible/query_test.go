@@ -0,0 +1,26 @@
+package bible
+
+import "testing"
+
+func TestLoadBibleFileRollsBack(t *testing.T) {
+	// "1" and "01" are the same verse, which the second insert fails on
+	path := writeBibleFile(t, map[string][]verse{
+		"Jude": {{"1", "First."}, {"01", "Again."}},
+	})
+
+	err := loadBibleFile(path, "ROLLBACK", func(string, int, int, int) {})
+	if err == nil {
+		t.Fatal("loadBibleFile succeeded, want an error for the repeated verse")
+	}
+	if inUse := DB.Stats().InUse; inUse != 0 {
+		t.Errorf("%d connections still in use, want the transaction rolled back", inUse)
+	}
+
+	var count int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM bible WHERE translation = ?", "ROLLBACK").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("%d verses stored, want none", count)
+	}
+}
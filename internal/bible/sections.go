@@ -0,0 +1,102 @@
+package bible
+
+import (
+	"database/sql"
+	"sort"
+	"strconv"
+)
+
+// GetSectionHeading returns the heading of the section a verse is in, e.g. "The Creation",
+// which is the last heading at or before the verse in its book.
+// Returns ok=false when there is no heading before the verse.
+func GetSectionHeading(translation, book string, chapter, verse int) (heading string, ok bool, err error) {
+	query := `
+		SELECT heading
+		FROM section_headings
+		WHERE translation = ? AND book = ? AND (chapter < ? OR (chapter = ? AND verse <= ?))
+		ORDER BY chapter DESC, verse DESC
+		LIMIT 1
+	`
+	err = DB.QueryRow(query, translation, book, chapter, chapter, verse).Scan(&heading)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return heading, true, nil
+}
+
+// GetSectionHeadings returns the headings of a chapter by the verse they come before
+func GetSectionHeadings(translation, book string, chapter int) (map[int]string, error) {
+	rows, err := DB.Query(`
+		SELECT verse, heading
+		FROM section_headings
+		WHERE translation = ? AND book = ? AND chapter = ?
+	`, translation, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	headings := make(map[int]string)
+	for rows.Next() {
+		var verse int
+		var heading string
+		if err := rows.Scan(&verse, &heading); err != nil {
+			return nil, err
+		}
+		headings[verse] = heading
+	}
+	return headings, rows.Err()
+}
+
+// saveSectionHeadings stores the section headings of a chapter, from its "headings"
+// field and from <h> tags in its verses. Existing headings are kept.
+func saveSectionHeadings(tx *sql.Tx, translation, book string, chapter int, chapterData ChapterData) error {
+	headings := make(map[int]string)
+	for verseStr, text := range chapterData.Verses {
+		verse, err := strconv.Atoi(verseStr)
+		if err != nil {
+			continue // Skip invalid entries
+		}
+		_, spans := ParseMarkup(text)
+		for _, span := range spans {
+			if span.Kind == SpanHeading {
+				headings[verse] = span.Text
+				break
+			}
+		}
+	}
+	for verseStr, heading := range chapterData.Headings {
+		verse, err := strconv.Atoi(verseStr)
+		if err != nil || heading == "" {
+			continue // Skip invalid entries
+		}
+		headings[verse] = heading
+	}
+	if len(headings) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR IGNORE INTO section_headings (translation, book, chapter, verse, heading)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	verses := make([]int, 0, len(headings))
+	for verse := range headings {
+		verses = append(verses, verse)
+	}
+	sort.Ints(verses)
+	for _, verse := range verses {
+		if _, err := stmt.Exec(translation, book, chapter, verse, headings[verse]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
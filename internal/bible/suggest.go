@@ -0,0 +1,321 @@
+package bible
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Suggestion is a candidate reference for partially typed input
+type Suggestion struct {
+	Book      string `json:"book"`
+	Chapter   int    `json:"chapter"`
+	Verse     int    `json:"verse"`
+	Reference string `json:"reference"`
+	Preview   string `json:"preview"`
+}
+
+// previewLength is the maximum length of a suggestion's verse preview
+const previewLength = 80
+
+// referenceInput splits typed input into the book and an optional chapter and verse,
+// e.g. "1 jhn 3:1" or "john3"
+var referenceInput = regexp.MustCompile(`^(.*[^\d\s:.])\s*(\d+)(?:\s*[:.]\s*(\d*))?$`)
+
+// SuggestReferences returns up to limit references matching partially typed or
+// misspelled input such as "jhn 3:16", "1 co 13" or "mat". Books are matched by
+// prefix, alias and edit distance, and chapter and verse are checked against the
+// translation so only existing verses are suggested.
+func SuggestReferences(translation, input string, limit int) ([]Suggestion, error) {
+	bookInput, chapter, verse := splitReferenceInput(input)
+	if NormalizeBookName(bookInput) == "" {
+		return nil, nil
+	}
+
+	books, err := matchBooks(translation, bookInput)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []Suggestion
+	for _, match := range books {
+		if len(suggestions) >= limit {
+			break
+		}
+
+		// Without a verse, suggest the start of the book or chapter.
+		// A single exact book lists several verses to pick from.
+		var verses []*Verse
+		if verse > 0 {
+			v, ok, err := lookupVerse(translation, match.Book, max(chapter, 1), verse)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				verses = append(verses, v)
+			}
+		} else {
+			count := 1
+			if match.score == 0 {
+				count = limit - len(suggestions)
+			}
+			verses, err = firstVerses(translation, match.Book, chapter, count)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, v := range verses {
+			suggestions = append(suggestions, newSuggestion(v))
+		}
+	}
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// splitReferenceInput splits typed input into the book, chapter and verse.
+// Missing chapter or verse numbers are 0.
+func splitReferenceInput(input string) (book string, chapter, verse int) {
+	input = strings.TrimSpace(input)
+	m := referenceInput.FindStringSubmatch(input)
+	if m == nil {
+		return input, 0, 0
+	}
+
+	chapter, _ = strconv.Atoi(m[2])
+	verse, _ = strconv.Atoi(m[3])
+	return m[1], chapter, verse
+}
+
+// bookMatch is a book matched against typed input; lower scores are better
+type bookMatch struct {
+	Book  string
+	score int
+}
+
+// normalizedAlias is a book alias normalized for matching typed input
+type normalizedAlias struct {
+	book, alias string
+}
+
+// bookCache keeps the books of each translation and the book aliases, so
+// suggestions don't query the database on every key typed. It is dropped
+// when translations or aliases change.
+var bookCache struct {
+	sync.Mutex
+	books   map[string][]string // By translation
+	aliases []normalizedAlias   // nil until loaded
+}
+
+// cachedBooks returns the books of a translation in Bible order and every
+// normalized book alias, loading them into the cache if they aren't there
+func cachedBooks(translation string) ([]string, []normalizedAlias, error) {
+	bookCache.Lock()
+	defer bookCache.Unlock()
+
+	if bookCache.aliases == nil {
+		aliases, err := GetBookAliases()
+		if err != nil {
+			return nil, nil, err
+		}
+		bookCache.aliases = make([]normalizedAlias, 0, len(aliases))
+		for _, alias := range aliases {
+			bookCache.aliases = append(bookCache.aliases, normalizedAlias{alias.Book, NormalizeBookName(alias.Alias)})
+		}
+	}
+
+	books, ok := bookCache.books[translation]
+	if !ok {
+		var err error
+		if books, err = GetBooks(translation); err != nil {
+			return nil, nil, err
+		}
+		if bookCache.books == nil {
+			bookCache.books = make(map[string][]string)
+		}
+		bookCache.books[translation] = books
+	}
+	return books, bookCache.aliases, nil
+}
+
+// dropBookCache forgets the cached books and aliases after they changed
+func dropBookCache() {
+	bookCache.Lock()
+	defer bookCache.Unlock()
+	bookCache.books = nil
+	bookCache.aliases = nil
+}
+
+// matchBooks returns the books of a translation matching the typed book name, best first
+func matchBooks(translation, input string) ([]bookMatch, error) {
+	books, aliases, err := cachedBooks(translation)
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[string]int, len(books))
+	for i, book := range books {
+		available[book] = i
+	}
+
+	normalized := NormalizeBookName(input)
+	best := make(map[string]int)
+	for _, alias := range aliases {
+		if _, ok := available[alias.book]; !ok {
+			continue
+		}
+		score, ok := matchScore(normalized, alias.alias)
+		if !ok {
+			continue
+		}
+		if current, seen := best[alias.book]; !seen || score < current {
+			best[alias.book] = score
+		}
+	}
+
+	matches := make([]bookMatch, 0, len(best))
+	for book, score := range best {
+		matches = append(matches, bookMatch{Book: book, score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return available[matches[i].Book] < available[matches[j].Book]
+	})
+	return matches, nil
+}
+
+// matchScore scores typed input against a normalized alias.
+// 0 is an exact match, then prefix matches, then matches within a few typos.
+func matchScore(input, alias string) (int, bool) {
+	if input == alias {
+		return 0, true
+	}
+	if strings.HasPrefix(alias, input) {
+		return 1, true
+	}
+
+	allowed := maxTypos(input)
+	if d := editDistance(input, alias); d <= allowed {
+		return 1 + d, true
+	}
+
+	// Allow typos in a partially typed name, e.g. "jhon" for "johnson"
+	if utf8.RuneCountInString(alias) > utf8.RuneCountInString(input) {
+		prefix := string([]rune(alias)[:utf8.RuneCountInString(input)])
+		if d := editDistance(input, prefix); d <= allowed {
+			return 2 + d, true
+		}
+	}
+	return 0, false
+}
+
+// maxTypos returns how many typos are tolerated for input of this length
+func maxTypos(input string) int {
+	switch n := utf8.RuneCountInString(input); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the Damerau-Levenshtein (optimal string alignment) distance
+// between two strings, so swapped letters count as a single typo
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+// lookupVerse fetches a verse, returning ok=false when it doesn't exist
+func lookupVerse(translation, book string, chapter, verse int) (*Verse, bool, error) {
+	var v Verse
+	row := DB.QueryRow(`
+		SELECT id, translation, book, chapter, verse, text, markup
+		FROM bible
+		WHERE translation = ? AND book = ? AND chapter = ? AND verse = ?
+	`, translation, book, chapter, verse)
+	err := scanVerse(row, &v)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return &v, true, nil
+}
+
+// firstVerses fetches the first count verses of a chapter,
+// or of the whole book when chapter is 0
+func firstVerses(translation, book string, chapter, count int) ([]*Verse, error) {
+	query := `
+		SELECT id, translation, book, chapter, verse, text, markup
+		FROM bible
+		WHERE translation = ? AND book = ? AND (? = 0 OR chapter = ?)
+		ORDER BY chapter, verse
+		LIMIT ?
+	`
+	rows, err := DB.Query(query, translation, book, chapter, chapter, count)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verses []*Verse
+	for rows.Next() {
+		var v Verse
+		if err := scanVerse(rows, &v); err != nil {
+			return nil, err
+		}
+		verses = append(verses, &v)
+	}
+
+	return verses, rows.Err()
+}
+
+// newSuggestion builds the suggestion for a verse with a shortened preview
+func newSuggestion(v *Verse) Suggestion {
+	preview := v.Text
+	if utf8.RuneCountInString(preview) > previewLength {
+		preview = string([]rune(preview)[:previewLength-1]) + "…"
+	}
+
+	return Suggestion{
+		Book:      v.Book,
+		Chapter:   v.Chapter,
+		Verse:     v.Verse,
+		Reference: fmt.Sprintf("%s %d:%d", v.Book, v.Chapter, v.Verse),
+		Preview:   preview,
+	}
+}
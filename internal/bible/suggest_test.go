@@ -0,0 +1,78 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"john", "john", 0},
+		{"john", "", 4},
+		{"", "mark", 4},
+		{"jhon", "john", 1}, // Swapped letters are one typo
+		{"jonh", "john", 1},
+		{"jon", "john", 1},
+		{"johnn", "john", 1},
+		{"mark", "matt", 2},
+		{"kitten", "sitting", 3},
+		{"génesis", "genesis", 1}, // Letters, not bytes
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := editDistance(tt.b, tt.a); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchBooksCache(t *testing.T) {
+	match := func(input string) []string {
+		t.Helper()
+		matches, err := matchBooks("CACHE", input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var books []string
+		for _, m := range matches {
+			books = append(books, m.Book)
+		}
+		return books
+	}
+
+	// Nothing matches before the translation is imported
+	if books := match("Jude"); books != nil {
+		t.Errorf("before the import: %q, want none", books)
+	}
+
+	path := writeBibleFile(t, map[string][]verse{"Jude": numberedVerses(25)})
+	if err := loadBibleFile(path, "CACHE", func(string, int, int, int) {}); err != nil {
+		t.Fatal(err)
+	}
+	if books, want := match("Jude"), []string{"Jude"}; !reflect.DeepEqual(books, want) {
+		t.Errorf("after the import: %q, want %q", books, want)
+	}
+
+	if books := match("Brudder"); books != nil {
+		t.Errorf("before adding the alias: %q, want none", books)
+	}
+	if err := AddBookAlias(BookAlias{Language: "x-test", Book: "Jude", Alias: "Brudder", Kind: AliasKindAlias}); err != nil {
+		t.Fatal(err)
+	}
+	if books, want := match("Brudder"), []string{"Jude"}; !reflect.DeepEqual(books, want) {
+		t.Errorf("after adding the alias: %q, want %q", books, want)
+	}
+
+	if _, ok := bookCache.books["CACHE"]; !ok {
+		t.Error("the books of the translation aren't cached")
+	}
+}
@@ -0,0 +1,264 @@
+package bible
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// TranslationInfo describes a translation in the database and how it is offered to the operator
+type TranslationInfo struct {
+	Name      string
+	Language  string
+	Visible   bool // Offered in the translation list
+	Default   bool // Selected when the controller starts
+	SortOrder int
+}
+
+// GetTranslations returns all translations with their settings, in display order
+func GetTranslations() ([]*TranslationInfo, error) {
+	rows, err := DB.Query(`
+		SELECT b.translation,
+			COALESCE(t.language, ?),
+			COALESCE(t.visible, 1),
+			COALESCE(t.is_default, 0),
+			COALESCE(t.sort_order, 0)
+		FROM (SELECT DISTINCT translation FROM bible) b
+		LEFT JOIN translations t ON t.name = b.translation
+		ORDER BY 5, b.translation
+	`, DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*TranslationInfo
+	for rows.Next() {
+		info := &TranslationInfo{}
+		if err := rows.Scan(&info.Name, &info.Language, &info.Visible, &info.Default, &info.SortOrder); err != nil {
+			return nil, err
+		}
+		translations = append(translations, info)
+	}
+	return translations, rows.Err()
+}
+
+// GetVisibleTranslations returns the names of the translations offered to the operator, in display order
+func GetVisibleTranslations() ([]string, error) {
+	translations, err := GetTranslations()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, info := range translations {
+		if info.Visible {
+			names = append(names, info.Name)
+		}
+	}
+	return names, nil
+}
+
+// GetDefaultTranslation returns the default translation, or the first visible one when
+// there is no visible default. It returns "" when there are no visible translations.
+func GetDefaultTranslation() (string, error) {
+	translations, err := GetTranslations()
+	if err != nil {
+		return "", err
+	}
+
+	first := ""
+	for _, info := range translations {
+		if !info.Visible {
+			continue
+		}
+		if info.Default {
+			return info.Name, nil
+		}
+		if first == "" {
+			first = info.Name
+		}
+	}
+	return first, nil
+}
+
+// SetTranslationVisible shows or hides a translation in the translation list.
+// The last visible translation can't be hidden.
+func SetTranslationVisible(translation string, visible bool) error {
+	if !visible {
+		names, err := GetVisibleTranslations()
+		if err != nil {
+			return err
+		}
+		if len(names) == 1 && names[0] == translation {
+			return errors.New("at least one translation must be visible")
+		}
+	}
+
+	return updateTranslation(translation, "UPDATE translations SET visible = ? WHERE name = ?", visible, translation)
+}
+
+// SetDefaultTranslation makes a translation the one selected on startup
+func SetDefaultTranslation(translation string) error {
+	return updateTranslation(translation, "UPDATE translations SET is_default = (name = ?)", translation)
+}
+
+// SetTranslationOrder sets the display order of translations. Translations that
+// aren't listed keep their position after the listed ones.
+func SetTranslationOrder(translations []string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE translations SET sort_order = ?", len(translations)); err != nil {
+		return err
+	}
+	for i, translation := range translations {
+		if err := addTranslationRow(tx, translation); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE translations SET sort_order = ? WHERE name = ?", i, translation); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RenameTranslation renames a translation, e.g. to fix its abbreviation, along with its data file
+func RenameTranslation(translation, name string) error {
+	name = strings.TrimSpace(name)
+	if name == translation {
+		return nil
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid translation name: %s", name)
+	}
+	if err := checkNewTranslation(name); err != nil {
+		return err
+	}
+	if err := checkTranslation(translation); err != nil {
+		return err
+	}
+
+	// Keep seeding from loading the data file under either name meanwhile
+	seedMutex.Lock()
+	defer seedMutex.Unlock()
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"bible", "chapter_headers", "section_headings"} {
+		if _, err := tx.Exec("UPDATE "+table+" SET translation = ? WHERE translation = ?", name, translation); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("UPDATE translations SET name = ? WHERE name = ?", name, translation); err != nil {
+		return err
+	}
+
+	// Rename the data file too, or it would be seeded again under the old name
+	renamed := false
+	if err := os.Rename(TranslationFile(translation), TranslationFile(name)); err == nil {
+		renamed = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rename data file: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		if renamed {
+			os.Rename(TranslationFile(name), TranslationFile(translation))
+		}
+		return err
+	}
+	dropBookCache()
+	slog.Info("Renamed translation", logging.Translation(translation), "name", name)
+	return nil
+}
+
+// DeleteTranslation removes a translation with its chapter headers, section
+// headings and settings, and deletes its data file so it isn't seeded again
+func DeleteTranslation(translation string) error {
+	if err := checkTranslation(translation); err != nil {
+		return err
+	}
+
+	seedMutex.Lock()
+	defer seedMutex.Unlock()
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"bible", "chapter_headers", "section_headings"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE translation = ?", translation); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM translations WHERE name = ?", translation); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	dropBookCache()
+
+	slog.Info("Deleted translation", logging.Translation(translation))
+
+	if err := os.Remove(TranslationFile(translation)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete data file: %w", err)
+	}
+	return nil
+}
+
+// updateTranslation runs an update of translation settings, adding the
+// settings row of the translation first if it has none
+func updateTranslation(translation, query string, args ...any) error {
+	if err := checkTranslation(translation); err != nil {
+		return err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := addTranslationRow(tx, translation); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// addTranslationRow adds the settings row of a translation unless it exists
+func addTranslationRow(tx *sql.Tx, translation string) error {
+	_, err := tx.Exec("INSERT INTO translations (name) VALUES (?) ON CONFLICT(name) DO NOTHING", translation)
+	return err
+}
+
+// checkTranslation returns an error if a translation isn't in the database
+func checkTranslation(translation string) error {
+	var count int
+	err := DB.QueryRow("SELECT COUNT(*) FROM bible WHERE translation = ? LIMIT 1", translation).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("translation not found: %s", translation)
+	}
+	return nil
+}
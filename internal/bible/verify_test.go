@@ -0,0 +1,206 @@
+package bible
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// verse is a verse key and text as written in a test file
+type verse struct {
+	key, text string
+}
+
+// numberedVerses returns verses 1 to n with some text
+func numberedVerses(n int) []verse {
+	verses := make([]verse, n)
+	for i := range verses {
+		verses[i] = verse{strconv.Itoa(i + 1), fmt.Sprintf("Text of verse %d.", i+1)}
+	}
+	return verses
+}
+
+// writeBibleFile writes a Bible JSON file with one chapter per book, keeping
+// the order and duplicates of the verses, and returns its path
+func writeBibleFile(t *testing.T, books map[string][]verse) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString(`{"version": "TEST", "books": {`)
+	first := true
+	for book, verses := range books {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&b, `%q: {"1": {"verses": {`, book)
+		for i, v := range verses {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q: %q", v.key, v.text)
+		}
+		b.WriteString("}}}")
+	}
+	b.WriteString("}}")
+
+	path := filepath.Join(t.TempDir(), "TEST.json")
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifyFile(t *testing.T) {
+	// with returns the 25 verses of Jude 1 changed by edit
+	with := func(edit func([]verse) []verse) []verse {
+		return edit(numberedVerses(25))
+	}
+
+	tests := []struct {
+		name   string
+		books  map[string][]verse
+		issues []string // Issues with a location; missing testaments are left out
+		verses int
+	}{
+		{
+			name:   "complete",
+			books:  map[string][]verse{"Jude": numberedVerses(25)},
+			verses: 25,
+		},
+		{
+			name: "missing verse",
+			books: map[string][]verse{"Jude": with(func(v []verse) []verse {
+				return append(v[:4], v[7:]...)
+			})},
+			issues: []string{"error: Jude 1: missing verse 5-7"},
+			verses: 22,
+		},
+		{
+			name:   "chapter cut short",
+			books:  map[string][]verse{"Jude": numberedVerses(23)},
+			issues: []string{"warning: Jude 1: ends at verse 23, expected 25"},
+			verses: 23,
+		},
+		{
+			name: "duplicate verse",
+			books: map[string][]verse{"Jude": with(func(v []verse) []verse {
+				return append(v, verse{"3", "Again."})
+			})},
+			issues: []string{
+				"error: Jude 1:3: verse appears more than once; only the last one is loaded",
+				"warning: Jude 1: verses are out of order",
+			},
+			verses: 25,
+		},
+		{
+			name: "invalid and padded keys",
+			books: map[string][]verse{"Jude": with(func(v []verse) []verse {
+				v[6].key = "07"
+				return append(v, verse{"x", "Stray."})
+			})},
+			issues: []string{
+				`warning: Jude 1: verse "07" is loaded as verse 7`,
+				`error: Jude 1: invalid verse "x" is skipped when loading`,
+			},
+			verses: 25,
+		},
+		{
+			name: "keys sorted as text are in order",
+			books: map[string][]verse{"Jude": with(func(v []verse) []verse {
+				// 1, 10-19, 2, 20-25, 3-9
+				var sorted []verse
+				for _, prefix := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"} {
+					for _, verse := range v {
+						if verse.key == prefix || (len(verse.key) == 2 && verse.key[:1] == prefix) {
+							sorted = append(sorted, verse)
+						}
+					}
+				}
+				return sorted
+			})},
+			verses: 25,
+		},
+		{
+			name: "empty and suspicious text",
+			books: map[string][]verse{"Jude": with(func(v []verse) []verse {
+				v[3].text = " "
+				v[4].text = "Godâ€™s grace"
+				v[5].text = "love &amp; peace"
+				v[6].text = "<J>Red letters</J> are fine"
+				return v
+			})},
+			issues: []string{
+				"error: Jude 1:4: empty text",
+				`warning: Jude 1:5: contains "â€", which looks like wrongly decoded UTF-8`,
+				`warning: Jude 1:6: contains the HTML entity "&amp;"`,
+			},
+			verses: 25,
+		},
+		{
+			name:   "unknown book",
+			books:  map[string][]verse{"Jude 2": numberedVerses(3)},
+			issues: []string{"warning: Jude 2: not a canonical book name, so references can't find it"},
+			verses: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := VerifyFile(writeBibleFile(t, tt.books))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var issues []string
+			for _, issue := range report.Issues {
+				if issue.Location != "" {
+					issues = append(issues, issue.String())
+				}
+			}
+			if !reflect.DeepEqual(issues, tt.issues) {
+				t.Errorf("issues =\n  %s\nwant\n  %s", strings.Join(issues, "\n  "), strings.Join(tt.issues, "\n  "))
+			}
+			if report.Verses != tt.verses {
+				t.Errorf("verses = %d, want %d", report.Verses, tt.verses)
+			}
+		})
+	}
+}
+
+func TestVerifyFileChapters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TEST.json")
+	data := `{"books": {"Philemon": {"1": {"verses": {"1": "Paul."}}, "3": {"verses": {"1": "Extra."}}}}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := VerifyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"warning: Philemon 1: ends at verse 1, expected 25",
+		"error: Philemon: missing chapter 2",
+		"warning: Philemon: has 2 chapters, expected 1",
+		"warning: the Old Testament is missing",
+		"warning: missing 26 books of the New Testament: " + strings.Join(CanonicalBooks[oldTestamentBooks:oldTestamentBooks+17], ", ") +
+			", " + strings.Join(CanonicalBooks[oldTestamentBooks+18:], ", "),
+	}
+	var got []string
+	for _, issue := range report.Issues {
+		got = append(got, issue.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues =\n  %s\nwant\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+	if report.Books != 1 || report.Chapters != 2 || report.Verses != 2 {
+		t.Errorf("counts = %d books, %d chapters, %d verses; want 1, 2, 2", report.Books, report.Chapters, report.Verses)
+	}
+	if report.Count(SeverityError) != 1 {
+		t.Errorf("errors = %d, want 1", report.Count(SeverityError))
+	}
+}
@@ -0,0 +1,128 @@
+// Package cli implements the command line commands of Mr Verse,
+// which run instead of the UI when a command is given
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+)
+
+// command is a command line command
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// commands are the available command line commands by name
+var commands = map[string]command{
+	"export-image": {
+		usage: "Export a verse or passage as a PNG or JPEG image",
+		run:   exportImage,
+	},
+	"export-log": {
+		usage: "Export the service log as CSV, Markdown or JSON",
+		run:   exportLog,
+	},
+	"export-playlist": {
+		usage: "Export a list of references as a PPTX or ODP deck or a PDF handout",
+		run:   exportPlaylist,
+	},
+	"export-translation": {
+		usage: "Export a translation as JSON, CSV or OSIS XML",
+		run:   exportTranslation,
+	},
+	"verify": {
+		usage: "Check translations for missing, duplicate or damaged verses",
+		run:   verify,
+	},
+}
+
+// Run runs the command named by the first argument
+func Run(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(os.Stdout)
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(os.Stderr)
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+	err := cmd.run(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil // Usage was already printed
+	}
+	return err
+}
+
+// printUsage lists the available commands
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: mr-verse [command] [flags]")
+	fmt.Fprintln(w, "\nWithout a command the application window opens.")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-19s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w, "\nRun 'mr-verse [command] -h' for the flags of a command,")
+	fmt.Fprintln(w, "and 'mr-verse -h' for the flags that set where files are kept.")
+}
+
+// newFlagSet creates the flag set for a command
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("mr-verse "+name, flag.ContinueOnError)
+}
+
+// openDB opens the database for a command.
+// The returned function closes it again.
+func openDB() (func(), error) {
+	if err := bible.InitDB(); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return bible.CloseDB, nil
+}
+
+// resolveTranslation returns the translation to use, or the default one for ""
+func resolveTranslation(translation string) (string, error) {
+	if translation != "" {
+		return translation, nil
+	}
+	translation, err := bible.GetDefaultTranslation()
+	if err != nil {
+		return "", fmt.Errorf("failed to list translations: %w", err)
+	}
+	if translation == "" {
+		return "", fmt.Errorf("no translations available")
+	}
+	return translation, nil
+}
+
+// createOutput opens the output file of a command, or stdout for "" or "-"
+func createOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+// nopCloser keeps stdout open when a command is done writing
+type nopCloser struct {
+	io.Writer
+}
+
+// Close does nothing
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/ui"
+)
+
+// exportImage draws a verse or passage the way the live window shows it and saves it as an image
+func exportImage(args []string) error {
+	fs := newFlagSet("export-image")
+	translation := fs.String("t", "", "translation (default: the default translation)")
+	theme := fs.String("theme", "", "slide theme, e.g. Light (default: Default)")
+	size := fs.String("size", presentation.ImageSizes[0], "image size, e.g. "+strings.Join(presentation.ImageSizes, ", "))
+	background := fs.String("background", "", "background file from the media folder")
+	mode := fs.String("mode", media.ModeFill, "background scaling: "+strings.Join(media.Modes, ", "))
+	dim := fs.Float64("dim", 0, "darken the background from 0 to 1")
+	section := fs.Bool("section", false, "show the section heading above the reference")
+	output := fs.String("o", "", "output file ending in .png or .jpg (required)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mr-verse export-image [flags] REFERENCE")
+		fmt.Fprintln(fs.Output(), "\nExample: mr-verse export-image -size 1080x1080 -o verse.png John 3:16-17")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	reference := strings.Join(fs.Args(), " ")
+	if reference == "" {
+		return fmt.Errorf("missing reference, e.g. John 3:16")
+	}
+	if *output == "" {
+		return fmt.Errorf("missing output file, use -o verse.png")
+	}
+	width, height, err := presentation.ParseImageSize(*size)
+	if err != nil {
+		return err
+	}
+
+	closeDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	*translation, err = resolveTranslation(*translation)
+	if err != nil {
+		return err
+	}
+	language, err := bible.GetTranslationLanguage(*translation)
+	if err != nil {
+		return err
+	}
+	book, chapter, first, last, err := bible.ParsePassageIn(reference, language)
+	if err != nil {
+		return err
+	}
+
+	verses, err := bible.GetPassage(*translation, book, chapter, first, last)
+	if err != nil {
+		return err
+	}
+
+	slide := presentation.NewPassageSlide(verses)
+	slide.Theme = *theme
+	slide.Background = *background
+	slide.BackgroundMode = *mode
+	slide.BackgroundDim = *dim
+
+	if err := ui.ExportImage(*output, slide, width, height, *section); err != nil {
+		return fmt.Errorf("failed to export image: %w", err)
+	}
+	fmt.Printf("Exported %s to %s\n", slide.Title, *output)
+	return nil
+}
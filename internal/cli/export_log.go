@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/servicelog"
+)
+
+// exportLog exports the service log for a date range
+func exportLog(args []string) error {
+	fs := newFlagSet("export-log")
+	format := fs.String("format", servicelog.FormatCSV, "export format: "+strings.Join(servicelog.Formats, ", "))
+	fromDate := fs.String("from", "", "first day to export, YYYY-MM-DD (default: no limit)")
+	toDate := fs.String("to", "", "last day to export, YYYY-MM-DD (default: no limit)")
+	output := fs.String("o", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	from, to, err := servicelog.DateRange(*fromDate, *toDate)
+	if err != nil {
+		return err
+	}
+
+	closeDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if err := servicelog.Init(bible.DB); err != nil {
+		return fmt.Errorf("failed to open service log: %w", err)
+	}
+
+	entries, err := servicelog.GetEntries(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to read service log: %w", err)
+	}
+
+	out, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return servicelog.Export(out, *format, entries)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/playlist"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// exportPlaylist exports a list of references as a slide deck or a PDF handout
+func exportPlaylist(args []string) error {
+	fs := newFlagSet("export-playlist")
+	translation := fs.String("t", "", "translation (default: the default translation)")
+	theme := fs.String("theme", "", "slide theme of decks, e.g. Light (default: Default)")
+	format := fs.String("format", "", "export format: "+strings.Join(playlist.Formats, ", ")+" (default: from the output file)")
+	output := fs.String("o", "", "output file (required)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mr-verse export-playlist [flags] FILE")
+		fmt.Fprintln(fs.Output(), "\nFILE lists one reference per line, e.g. John 3:16-18; use - to read from stdin.")
+		fmt.Fprintln(fs.Output(), "Lines starting with # are comments.")
+		fmt.Fprintln(fs.Output(), "\nExample: mr-verse export-playlist -theme Light -o sunday.pptx sunday.txt")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("missing playlist file, e.g. sunday.txt")
+	}
+	if *output == "" {
+		return fmt.Errorf("missing output file, use -o sunday.pptx")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*output), ".")
+	}
+	*format = strings.ToLower(*format)
+	if !slices.Contains(playlist.Formats, *format) {
+		return fmt.Errorf("unsupported export format %q, use %s", *format, strings.Join(playlist.Formats, ", "))
+	}
+
+	var data []byte
+	var err error
+	if fs.Arg(0) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	closeDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	*translation, err = resolveTranslation(*translation)
+	if err != nil {
+		return err
+	}
+
+	items, err := playlist.Load(*translation, playlist.ParseReferences(string(data)))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := playlist.Export(f, *format, items, presentation.GetTheme(*theme)); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to export playlist: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d passages to %s\n", len(items), *output)
+	return nil
+}
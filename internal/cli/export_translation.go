@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+)
+
+// exportTranslation exports a translation, or some of its books, as JSON, CSV or OSIS XML
+func exportTranslation(args []string) error {
+	fs := newFlagSet("export-translation")
+	books := fs.String("books", "", "books to export, e.g. John or Matthew-John (default: all)")
+	format := fs.String("format", "", "export format: "+strings.Join(bible.ExportFormats, ", ")+" (default: from the output file, or json)")
+	output := fs.String("o", "", "output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mr-verse export-translation [flags] TRANSLATION")
+		fmt.Fprintln(fs.Output(), "\nExample: mr-verse export-translation -books Matthew-John -o NLT.osis.xml NLT")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("missing translation, e.g. NLT")
+	}
+	if *format == "" {
+		*format = translationFormat(*output)
+	}
+
+	closeDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	selected, err := bible.ParseBookRange(fs.Arg(0), *books)
+	if err != nil {
+		return err
+	}
+
+	out, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return bible.ExportTranslation(out, *format, fs.Arg(0), selected)
+}
+
+// translationFormat returns the export format for an output file, e.g. "osis" for .xml
+func translationFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return bible.ExportFormatCSV
+	case ".xml", ".osis":
+		return bible.ExportFormatOSIS
+	default:
+		return bible.ExportFormatJSON
+	}
+}
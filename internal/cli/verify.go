@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+)
+
+// verify checks translations in the database or Bible JSON files for missing,
+// duplicate or damaged verses
+func verify(args []string) error {
+	fs := newFlagSet("verify")
+	errorsOnly := fs.Bool("errors", false, "list only errors, not warnings")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mr-verse verify [flags] [TRANSLATION | FILE.json ...]")
+		fmt.Fprintln(fs.Output(), "\nWithout arguments all translations in the database are verified.")
+		fmt.Fprintln(fs.Output(), "\nExample: mr-verse verify NLT data/KJV.json")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var translations, files []string
+	for _, arg := range fs.Args() {
+		if strings.HasSuffix(strings.ToLower(arg), ".json") {
+			files = append(files, arg)
+		} else {
+			translations = append(translations, arg)
+		}
+	}
+
+	var reports []*bible.Report
+	for _, file := range files {
+		report, err := bible.VerifyFile(file)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	if len(translations) > 0 || len(files) == 0 {
+		closeDB, err := openDB()
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		if len(translations) == 0 {
+			if translations, err = bible.GetAvailableTranslations(); err != nil {
+				return fmt.Errorf("failed to list translations: %w", err)
+			}
+			if len(translations) == 0 {
+				return fmt.Errorf("no translations available")
+			}
+		}
+		for _, translation := range translations {
+			report, err := bible.VerifyTranslation(translation)
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+	}
+
+	errors := 0
+	for _, report := range reports {
+		fmt.Println(report.Summary())
+		for _, issue := range report.Issues {
+			if *errorsOnly && issue.Severity != bible.SeverityError {
+				continue
+			}
+			fmt.Println("  " + issue.String())
+		}
+		errors += report.Count(bible.SeverityError)
+	}
+
+	if errors > 0 {
+		return fmt.Errorf("found %d errors", errors)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fyne.io/fyne/v2"
+	"github.com/mr-ministry/mr-verse/internal/media"
 )
 
 // MonitorBounds represents the position and size of a monitor
@@ -18,10 +19,21 @@ const (
 	PrefKeyMonitorY      = "secondaryMonitor.y"
 	PrefKeyMonitorWidth  = "secondaryMonitor.width"
 	PrefKeyMonitorHeight = "secondaryMonitor.height"
+
+	PrefKeyLobbyVerseList = "lobby.verseList"
+
+	PrefKeyShowSectionHeadings = "showSectionHeadings"
+
+	PrefKeyPlaylist = "playlist.references"
 )
 
-// GetMonitorBounds retrieves the secondary monitor bounds from app preferences
+// GetMonitorBounds retrieves the live window bounds from the configuration file,
+// or else the secondary monitor bounds from app preferences
 func GetMonitorBounds(preferences fyne.Preferences) *MonitorBounds {
+	if bounds := Current().LiveWindow.MonitorBounds(); bounds != nil {
+		return bounds
+	}
+
 	// Check if all required values are present
 	x := preferences.Int(PrefKeyMonitorX)
 	y := preferences.Int(PrefKeyMonitorY)
@@ -54,3 +66,40 @@ func SaveMonitorBounds(preferences fyne.Preferences, bounds *MonitorBounds) {
 	preferences.SetInt(PrefKeyMonitorWidth, bounds.Width)
 	preferences.SetInt(PrefKeyMonitorHeight, bounds.Height)
 }
+
+// prefKeyThemeBackground returns the preference key prefix for a slide theme's background
+func prefKeyThemeBackground(theme string) string {
+	return "themeBackground." + theme
+}
+
+// GetThemeBackground retrieves a slide theme's background from app preferences,
+// or nil if the theme has none
+func GetThemeBackground(preferences fyne.Preferences, theme string) *media.Background {
+	key := prefKeyThemeBackground(theme)
+	file := preferences.String(key + ".file")
+	if file == "" {
+		return nil
+	}
+
+	return &media.Background{
+		File: file,
+		Mode: preferences.StringWithFallback(key+".mode", media.ModeFill),
+		Dim:  preferences.Float(key + ".dim"),
+	}
+}
+
+// SaveThemeBackground saves a slide theme's background to app preferences.
+// A nil background removes it.
+func SaveThemeBackground(preferences fyne.Preferences, theme string, background *media.Background) {
+	key := prefKeyThemeBackground(theme)
+	if background == nil {
+		preferences.RemoveValue(key + ".file")
+		preferences.RemoveValue(key + ".mode")
+		preferences.RemoveValue(key + ".dim")
+		return
+	}
+
+	preferences.SetString(key+".file", background.File)
+	preferences.SetString(key+".mode", background.Mode)
+	preferences.SetFloat(key+".dim", background.Dim)
+}
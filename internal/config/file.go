@@ -0,0 +1,350 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// FileVersion is the version of the configuration file format
+const FileVersion = 1
+
+// File is the configuration file, config.toml. Flags and environment
+// variables take precedence over it, and it over the defaults.
+type File struct {
+	Version    int                               `toml:"version"`
+	Paths      Paths                             `toml:"paths"`
+	Bible      Bible                             `toml:"bible"`
+	Network    Network                           `toml:"network"`
+	Logging    Logging                           `toml:"logging"`
+	LiveWindow LiveWindow                        `toml:"live_window"`
+	Themes     map[string]presentation.ThemeSpec `toml:"themes"`
+	Keymap     map[string]string                 `toml:"keymap"`
+}
+
+// Paths are where files are kept; empty paths use the default layout.
+// Relative paths are relative to the configuration file's folder.
+type Paths struct {
+	Home         string `toml:"home"`
+	Database     string `toml:"database"`
+	Translations string `toml:"translations"`
+	Logs         string `toml:"logs"`
+	Media        string `toml:"media"`
+}
+
+// Bible configures what is shown at startup
+type Bible struct {
+	DefaultTranslation string `toml:"default_translation"` // Overrides the default set in the Translations tab
+	StartupVerse       string `toml:"startup_verse"`
+}
+
+// Network configures the HTTP server of the web stage display
+type Network struct {
+	HTTPAddr string `toml:"http_addr"` // e.g. "127.0.0.1:8080"; empty disables the server
+	Token    string `toml:"token"`     // Required to control the presentation remotely; empty turns remote control off
+}
+
+// Logging configures the application log
+type Logging struct {
+	Level  string `toml:"level"`
+	Format string `toml:"format"`
+}
+
+// LiveWindow is the size and position of the live window,
+// which take precedence over the monitor saved in the settings
+type LiveWindow struct {
+	X      int `toml:"x"`
+	Y      int `toml:"y"`
+	Width  int `toml:"width"`
+	Height int `toml:"height"`
+}
+
+// Default returns the configuration used when there is no configuration file
+func Default() *File {
+	return &File{
+		Version: FileVersion,
+		Bible: Bible{
+			StartupVerse: "John 3:16",
+		},
+		Logging: Logging{
+			Level:  "info",
+			Format: logging.FormatText,
+		},
+		Themes: map[string]presentation.ThemeSpec{},
+		Keymap: DefaultKeymap(),
+	}
+}
+
+// ValidationError lists the problems found in a configuration file,
+// each naming the setting it is about
+type ValidationError struct {
+	Path     string
+	Problems []string
+}
+
+// Error lists the problems, one per line
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration file %s:\n  %s", e.Path, strings.Join(e.Problems, "\n  "))
+}
+
+// Load reads the configuration file at path over the defaults.
+// A missing file gives the defaults. Syntax errors name the line they are on,
+// and all problems with the settings are returned in a *ValidationError.
+func Load(path string) (*File, error) {
+	f := Default()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	meta, err := toml.Decode(string(data), f)
+	if err != nil {
+		// e.g. line 3 (last key "network.http_addr"): incompatible types
+		return nil, fmt.Errorf("invalid configuration file %s: %s", path, strings.TrimPrefix(err.Error(), "toml: "))
+	}
+
+	var problems []string
+	for _, key := range meta.Undecoded() {
+		problems = append(problems, fmt.Sprintf("%s: unknown setting", key))
+	}
+	if !meta.IsDefined("version") {
+		problems = append(problems, fmt.Sprintf("version: missing; add version = %d", FileVersion))
+	}
+	problems = append(problems, f.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Path: path, Problems: problems}
+	}
+
+	f.Paths.resolve(filepath.Dir(path))
+	return f, nil
+}
+
+// versePattern matches a single verse reference, e.g. "John 3:16"
+var versePattern = regexp.MustCompile(`^\S.*\s\d+:\d+$`)
+
+// validate returns the problems with the settings, e.g. "network.http_addr: invalid port"
+func (f *File) validate() []string {
+	var problems []string
+	problem := func(key, format string, args ...any) {
+		problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+	}
+
+	if f.Version > FileVersion {
+		problem("version", "version %d is newer than this version of Mr Verse supports (%d)", f.Version, FileVersion)
+	} else if f.Version < 1 {
+		problem("version", "must be %d", FileVersion)
+	}
+
+	if verse := strings.TrimSpace(f.Bible.StartupVerse); verse != "" && !versePattern.MatchString(verse) {
+		problem("bible.startup_verse", "%q is not a verse reference such as \"John 3:16\"", f.Bible.StartupVerse)
+	}
+
+	if addr := f.Network.HTTPAddr; addr != "" {
+		if err := checkAddr(addr); err != nil {
+			problem("network.http_addr", "%v", err)
+		}
+	}
+
+	if f.Logging.Level != "" {
+		if _, err := logging.ParseLevel(f.Logging.Level); err != nil {
+			problem("logging.level", "%q is not one of debug, info, warn or error", f.Logging.Level)
+		}
+	}
+	if f.Logging.Format != "" && !slices.Contains(logging.Formats, f.Logging.Format) {
+		problem("logging.format", "%q is not one of %s", f.Logging.Format, strings.Join(logging.Formats, ", "))
+	}
+
+	w := f.LiveWindow
+	if w.Width < 0 || w.Height < 0 {
+		problem("live_window", "width and height can't be negative")
+	} else if (w.Width == 0) != (w.Height == 0) {
+		problem("live_window", "set both width and height, or neither")
+	}
+
+	names := make([]string, 0, len(f.Themes))
+	for name := range f.Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := f.Themes[name].Theme(); err != nil {
+			problem("themes."+name, "%v", err)
+		}
+	}
+
+	_, keymapProblems := ParseKeymap(f.Keymap)
+	return append(problems, keymapProblems...)
+}
+
+// checkAddr checks a listen address such as "127.0.0.1:8080" or ":8080"
+func checkAddr(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%q is not an address such as \"127.0.0.1:8080\"", addr)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q; use 1 to 65535", port)
+	}
+	return nil
+}
+
+// resolve makes relative paths relative to dir, and paths starting with ~ relative to the user's home
+func (p *Paths) resolve(dir string) {
+	for _, path := range []*string{&p.Home, &p.Database, &p.Translations, &p.Logs, &p.Media} {
+		if *path == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(*path, "~"); ok && (rest == "" || os.IsPathSeparator(rest[0])) {
+			if home, err := os.UserHomeDir(); err == nil {
+				*path = filepath.Join(home, rest)
+				continue
+			}
+		}
+		if !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, *path)
+		}
+	}
+}
+
+// Overrides returns the paths as locations for appdir
+func (p Paths) Overrides() appdir.Overrides {
+	return appdir.Overrides{
+		Home:         p.Home,
+		DB:           p.Database,
+		Translations: p.Translations,
+		Logs:         p.Logs,
+		Media:        p.Media,
+	}
+}
+
+// MonitorBounds returns the configured live window bounds, or nil if there are none
+func (w LiveWindow) MonitorBounds() *MonitorBounds {
+	if w.Width == 0 || w.Height == 0 {
+		return nil
+	}
+	return &MonitorBounds{X: w.X, Y: w.Y, Width: w.Width, Height: w.Height}
+}
+
+// current is the configuration in use, and loadErr why the file couldn't be used
+var (
+	currentMutex sync.RWMutex
+	current      = Default()
+	loadErr      error
+)
+
+// Current returns the configuration in use
+func Current() *File {
+	currentMutex.RLock()
+	defer currentMutex.RUnlock()
+	return current
+}
+
+// SetCurrent makes a configuration the one in use
+func SetCurrent(f *File) {
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	current = f
+}
+
+// LoadCurrent loads the configuration file at path and uses it. When the file
+// is invalid the defaults are used instead, and the error is kept for LoadError.
+func LoadCurrent(path string) error {
+	f, err := Load(path)
+	if err != nil {
+		f = Default()
+	}
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	current, loadErr = f, err
+	return err
+}
+
+// reload loads the configuration file at path again and uses it. When the file
+// is invalid the configuration in use is kept, and the error is kept for LoadError.
+func reload(path string) (*File, error) {
+	f, err := Load(path)
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	loadErr = err
+	if err != nil {
+		return nil, err
+	}
+	current = f
+	return f, nil
+}
+
+// LoadError returns why the configuration file was last rejected, if it was
+func LoadError() error {
+	currentMutex.RLock()
+	defer currentMutex.RUnlock()
+	return loadErr
+}
+
+// fixed are the settings given by flags or environment variables
+var fixed = map[string]bool{}
+
+// SetFixed marks settings, e.g. "logging.level", as given by a flag or an
+// environment variable, so reloading the configuration file leaves them alone
+func SetFixed(keys ...string) {
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	for _, key := range keys {
+		fixed[key] = true
+	}
+}
+
+// IsFixed reports whether a setting is given by a flag or an environment variable
+func IsFixed(key string) bool {
+	currentMutex.RLock()
+	defer currentMutex.RUnlock()
+	return fixed[key]
+}
+
+// HTTPAddr returns the address of the web stage display's HTTP server:
+// HTTP_ADDR, or else network.http_addr. An empty address disables the server.
+func HTTPAddr() string {
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		return addr
+	}
+	return Current().Network.HTTPAddr
+}
+
+// HTTPToken returns the token required to control the presentation over HTTP:
+// HTTP_TOKEN, or else network.token. An empty token turns remote control off.
+func HTTPToken() string {
+	if token := os.Getenv("HTTP_TOKEN"); token != "" {
+		return token
+	}
+	return Current().Network.Token
+}
+
+// AddThemes adds the configured slide themes, replacing themes with the same name
+func (f *File) AddThemes() []string {
+	names := make([]string, 0, len(f.Themes))
+	for name, spec := range f.Themes {
+		theme, err := spec.Theme()
+		if err != nil {
+			continue // Checked when loading
+		}
+		presentation.AddTheme(name, theme)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(f *File)
+		problems []string
+	}{
+		{
+			name:   "defaults",
+			change: func(f *File) {},
+		},
+		{
+			name:     "newer version",
+			change:   func(f *File) { f.Version = 2 },
+			problems: []string{"version: version 2 is newer than this version of Mr Verse supports (1)"},
+		},
+		{
+			name:     "version zero",
+			change:   func(f *File) { f.Version = 0 },
+			problems: []string{"version: must be 1"},
+		},
+		{
+			name:   "empty startup verse",
+			change: func(f *File) { f.Bible.StartupVerse = "" },
+		},
+		{
+			name:     "startup verse without a verse",
+			change:   func(f *File) { f.Bible.StartupVerse = "John 3" },
+			problems: []string{`bible.startup_verse: "John 3" is not a verse reference such as "John 3:16"`},
+		},
+		{
+			name:   "listen address",
+			change: func(f *File) { f.Network.HTTPAddr = "127.0.0.1:8080" },
+		},
+		{
+			name:     "address without a port",
+			change:   func(f *File) { f.Network.HTTPAddr = "8080" },
+			problems: []string{`network.http_addr: "8080" is not an address such as "127.0.0.1:8080"`},
+		},
+		{
+			name:     "port out of range",
+			change:   func(f *File) { f.Network.HTTPAddr = ":70000" },
+			problems: []string{`network.http_addr: invalid port "70000"; use 1 to 65535`},
+		},
+		{
+			name: "logging",
+			change: func(f *File) {
+				f.Logging.Level = "verbose"
+				f.Logging.Format = "xml"
+			},
+			problems: []string{
+				`logging.level: "verbose" is not one of debug, info, warn or error`,
+				`logging.format: "xml" is not one of text, json`,
+			},
+		},
+		{
+			name:   "live window",
+			change: func(f *File) { f.LiveWindow = LiveWindow{X: 1920, Width: 1920, Height: 1080} },
+		},
+		{
+			name:     "live window without a height",
+			change:   func(f *File) { f.LiveWindow.Width = 1920 },
+			problems: []string{"live_window: set both width and height, or neither"},
+		},
+		{
+			name:     "negative live window",
+			change:   func(f *File) { f.LiveWindow = LiveWindow{Width: -1, Height: 1080} },
+			problems: []string{"live_window: width and height can't be negative"},
+		},
+		{
+			name: "themes",
+			change: func(f *File) {
+				f.Themes["Evening"] = presentation.ThemeSpec{Background: "#1a1a2e"}
+				f.Themes["Broken"] = presentation.ThemeSpec{Foreground: "white"}
+			},
+			problems: []string{"themes.Broken: invalid color: white"},
+		},
+		{
+			name:     "keymap",
+			change:   func(f *File) { f.Keymap[ActionUpdateLive] = "F5" },
+			problems: []string{"keymap.update_live: F5 is already bound to toggle_live"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Default()
+			tt.change(f)
+			if problems := f.validate(); !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %q, want %q", problems, tt.problems)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		content  string
+		problems []string
+		wantErr  bool
+		check    func(t *testing.T, f *File)
+	}{
+		{
+			name:    "template",
+			content: template,
+			check: func(t *testing.T, f *File) {
+				if !reflect.DeepEqual(f, Default()) {
+					t.Errorf("Load(template) = %+v, want the defaults", f)
+				}
+			},
+		},
+		{
+			name: "settings",
+			content: "version = 1\n[paths]\ndatabase = \"data/bible.db\"\n" +
+				"[network]\nhttp_addr = \":8080\"\n[keymap]\nnext_verse = \"Down\"\n",
+			check: func(t *testing.T, f *File) {
+				if want := filepath.Join(dir, "data", "bible.db"); f.Paths.Database != want {
+					t.Errorf("database = %q, want %q", f.Paths.Database, want)
+				}
+				if f.Network.HTTPAddr != ":8080" {
+					t.Errorf("http_addr = %q, want \":8080\"", f.Network.HTTPAddr)
+				}
+				if f.Keymap[ActionNextVerse] != "Down" || f.Keymap[ActionPreviousVerse] != "PageUp" {
+					t.Errorf("keymap = %v, want next_verse changed and the other defaults kept", f.Keymap)
+				}
+			},
+		},
+		{
+			name:    "unknown settings and missing version",
+			content: "[network]\nhttp_port = 8080\n",
+			problems: []string{
+				"network.http_port: unknown setting",
+				"version: missing; add version = 1",
+			},
+		},
+		{
+			name:    "syntax error",
+			content: "version = 1\n[network\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.toml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := Load(path)
+			var validationErr *ValidationError
+			switch {
+			case tt.problems != nil:
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("Load() error = %v, want a *ValidationError", err)
+				}
+				if !reflect.DeepEqual(validationErr.Problems, tt.problems) {
+					t.Errorf("problems = %q, want %q", validationErr.Problems, tt.problems)
+				}
+			case tt.wantErr:
+				if err == nil || errors.As(err, &validationErr) {
+					t.Errorf("Load() error = %v, want a syntax error", err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			default:
+				tt.check(t, f)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		f, err := Load(filepath.Join(dir, "missing.toml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(f, Default()) {
+			t.Errorf("Load() = %+v, want the defaults", f)
+		}
+	})
+}
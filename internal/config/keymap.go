@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Keymap actions
+const (
+	ActionNextVerse     = "next_verse"
+	ActionPreviousVerse = "previous_verse"
+	ActionBack          = "back"
+	ActionForward       = "forward"
+	ActionToggleLive    = "toggle_live"
+	ActionUpdateLive    = "update_live"
+	ActionFocusSearch   = "focus_search"
+)
+
+// Actions lists the actions keys can be bound to
+var Actions = []string{
+	ActionNextVerse,
+	ActionPreviousVerse,
+	ActionBack,
+	ActionForward,
+	ActionToggleLive,
+	ActionUpdateLive,
+	ActionFocusSearch,
+}
+
+// DefaultKeymap returns the keys bound to actions unless configured otherwise
+func DefaultKeymap() map[string]string {
+	return map[string]string{
+		ActionNextVerse:     "PageDown",
+		ActionPreviousVerse: "PageUp",
+		ActionBack:          "Alt+Left",
+		ActionForward:       "Alt+Right",
+		ActionToggleLive:    "F5",
+		ActionUpdateLive:    "F6",
+		ActionFocusSearch:   "Ctrl+L",
+	}
+}
+
+// Key is a key with optional modifiers, e.g. "Ctrl+L" or "PageDown"
+type Key struct {
+	Name     fyne.KeyName
+	Modifier fyne.KeyModifier
+}
+
+// modifierNames are the names of modifiers in key bindings, lowercase
+var modifierNames = map[string]fyne.KeyModifier{
+	"ctrl":    fyne.KeyModifierControl,
+	"control": fyne.KeyModifierControl,
+	"alt":     fyne.KeyModifierAlt,
+	"option":  fyne.KeyModifierAlt,
+	"shift":   fyne.KeyModifierShift,
+	"super":   fyne.KeyModifierSuper,
+	"cmd":     fyne.KeyModifierSuper,
+	"command": fyne.KeyModifierSuper,
+}
+
+// keyNames are the names of keys in key bindings, lowercase
+var keyNames = map[string]fyne.KeyName{
+	"up":        fyne.KeyUp,
+	"down":      fyne.KeyDown,
+	"left":      fyne.KeyLeft,
+	"right":     fyne.KeyRight,
+	"pageup":    fyne.KeyPageUp,
+	"pagedown":  fyne.KeyPageDown,
+	"home":      fyne.KeyHome,
+	"end":       fyne.KeyEnd,
+	"insert":    fyne.KeyInsert,
+	"delete":    fyne.KeyDelete,
+	"backspace": fyne.KeyBackspace,
+	"return":    fyne.KeyReturn,
+	"enter":     fyne.KeyReturn,
+	"tab":       fyne.KeyTab,
+	"escape":    fyne.KeyEscape,
+	"space":     fyne.KeySpace,
+}
+
+// reservedKeys are the keys used with Ctrl, or Cmd on macOS, for editing text
+var reservedKeys = []fyne.KeyName{
+	fyne.KeyA, fyne.KeyC, fyne.KeyV, fyne.KeyX, fyne.KeyY, fyne.KeyZ, fyne.KeyInsert,
+}
+
+func init() {
+	for c := 'A'; c <= 'Z'; c++ {
+		keyNames[strings.ToLower(string(c))] = fyne.KeyName(string(c))
+	}
+	for c := '0'; c <= '9'; c++ {
+		keyNames[string(c)] = fyne.KeyName(string(c))
+	}
+	for i := 1; i <= 12; i++ {
+		keyNames[fmt.Sprintf("f%d", i)] = fyne.KeyName(fmt.Sprintf("F%d", i))
+	}
+}
+
+// ParseKey parses a key binding: a key name such as "PageDown", "F5" or "L",
+// optionally after modifiers joined with "+", e.g. "Ctrl+Shift+L"
+func ParseKey(s string) (Key, error) {
+	parts := strings.Split(s, "+")
+	name, ok := keyNames[strings.ToLower(strings.TrimSpace(parts[len(parts)-1]))]
+	if !ok {
+		return Key{}, fmt.Errorf("unknown key %q", parts[len(parts)-1])
+	}
+
+	key := Key{Name: name}
+	for _, part := range parts[:len(parts)-1] {
+		modifier, ok := modifierNames[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			return Key{}, fmt.Errorf("unknown modifier %q", part)
+		}
+		key.Modifier |= modifier
+	}
+
+	// Shift alone doesn't make a shortcut, and the editing shortcuts can't be rebound
+	if key.Modifier == fyne.KeyModifierShift {
+		return Key{}, fmt.Errorf("Shift must be combined with Ctrl, Alt or Super in %q", s)
+	}
+	if (key.Modifier == fyne.KeyModifierControl || key.Modifier == fyne.KeyModifierSuper) &&
+		slices.Contains(reservedKeys, key.Name) {
+		return Key{}, fmt.Errorf("%q is reserved for editing text", s)
+	}
+	return key, nil
+}
+
+// String returns the key binding, e.g. "Ctrl+L"
+func (k Key) String() string {
+	var parts []string
+	for _, m := range []struct {
+		modifier fyne.KeyModifier
+		name     string
+	}{
+		{fyne.KeyModifierControl, "Ctrl"},
+		{fyne.KeyModifierAlt, "Alt"},
+		{fyne.KeyModifierShift, "Shift"},
+		{fyne.KeyModifierSuper, "Super"},
+	} {
+		if k.Modifier&m.modifier != 0 {
+			parts = append(parts, m.name)
+		}
+	}
+	name := string(k.Name)
+	switch k.Name {
+	case fyne.KeyPageUp:
+		name = "PageUp"
+	case fyne.KeyPageDown:
+		name = "PageDown"
+	}
+	return strings.Join(append(parts, name), "+")
+}
+
+// ParseKeymap parses the key bindings of a keymap into the action of each key.
+// It returns a problem for each unknown action, invalid key or key bound twice.
+func ParseKeymap(keymap map[string]string) (map[Key]string, []string) {
+	actions := make([]string, 0, len(keymap))
+	for action := range keymap {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	keys := make(map[Key]string)
+	var problems []string
+	for _, action := range actions {
+		binding := keymap[action]
+		if !slices.Contains(Actions, action) {
+			problems = append(problems, fmt.Sprintf("keymap.%s: unknown action; use one of %s", action, strings.Join(Actions, ", ")))
+			continue
+		}
+		if binding == "" {
+			continue // Unbound
+		}
+		key, err := ParseKey(binding)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("keymap.%s: %v", action, err))
+			continue
+		}
+		if other, ok := keys[key]; ok {
+			problems = append(problems, fmt.Sprintf("keymap.%s: %s is already bound to %s", action, key, other))
+			continue
+		}
+		keys[key] = action
+	}
+	return keys, problems
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		binding string
+		want    Key
+		text    string
+		wantErr bool
+	}{
+		{binding: "PageDown", want: Key{Name: fyne.KeyPageDown}, text: "PageDown"},
+		{binding: "f5", want: Key{Name: fyne.KeyF5}, text: "F5"},
+		{binding: "Ctrl+L", want: Key{Name: fyne.KeyL, Modifier: fyne.KeyModifierControl}, text: "Ctrl+L"},
+		{binding: "control + l", want: Key{Name: fyne.KeyL, Modifier: fyne.KeyModifierControl}, text: "Ctrl+L"},
+		{binding: "Alt+Left", want: Key{Name: fyne.KeyLeft, Modifier: fyne.KeyModifierAlt}, text: "Alt+Left"},
+		{
+			binding: "Shift+Ctrl+1",
+			want:    Key{Name: fyne.Key1, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift},
+			text:    "Ctrl+Shift+1",
+		},
+		{binding: "Cmd+Enter", want: Key{Name: fyne.KeyReturn, Modifier: fyne.KeyModifierSuper}, text: "Super+Return"},
+		{binding: "Ctrl+Shift+C", want: Key{Name: fyne.KeyC, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift}, text: "Ctrl+Shift+C"},
+		{binding: "", wantErr: true},
+		{binding: "F13", wantErr: true},
+		{binding: "Hyper+L", wantErr: true},
+		{binding: "Shift+F5", wantErr: true},
+		{binding: "Ctrl+C", wantErr: true},
+		{binding: "Cmd+V", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binding, func(t *testing.T) {
+			got, err := ParseKey(tt.binding)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseKey(%q) = %v, want an error", tt.binding, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseKey(%q) = %+v, want %+v", tt.binding, got, tt.want)
+			}
+			if got.String() != tt.text {
+				t.Errorf("String() = %q, want %q", got.String(), tt.text)
+			}
+		})
+	}
+}
+
+func TestParseKeymap(t *testing.T) {
+	tests := []struct {
+		name     string
+		keymap   map[string]string
+		want     map[Key]string
+		problems []string
+	}{
+		{
+			name:   "defaults",
+			keymap: DefaultKeymap(),
+			want: map[Key]string{
+				{Name: fyne.KeyPageDown}:                             ActionNextVerse,
+				{Name: fyne.KeyPageUp}:                               ActionPreviousVerse,
+				{Name: fyne.KeyLeft, Modifier: fyne.KeyModifierAlt}:  ActionBack,
+				{Name: fyne.KeyRight, Modifier: fyne.KeyModifierAlt}: ActionForward,
+				{Name: fyne.KeyF5}:                                   ActionToggleLive,
+				{Name: fyne.KeyF6}:                                   ActionUpdateLive,
+				{Name: fyne.KeyL, Modifier: fyne.KeyModifierControl}: ActionFocusSearch,
+			},
+		},
+		{
+			name:   "empty key unbinds",
+			keymap: map[string]string{ActionNextVerse: "", ActionToggleLive: "F9"},
+			want:   map[Key]string{{Name: fyne.KeyF9}: ActionToggleLive},
+		},
+		{
+			name:     "unknown action",
+			keymap:   map[string]string{"jump": "F1"},
+			want:     map[Key]string{},
+			problems: []string{"keymap.jump: unknown action; use one of next_verse, previous_verse, back, forward, toggle_live, update_live, focus_search"},
+		},
+		{
+			name:     "invalid key",
+			keymap:   map[string]string{ActionBack: "Ctrl+X", ActionForward: "Right"},
+			want:     map[Key]string{{Name: fyne.KeyRight}: ActionForward},
+			problems: []string{`keymap.back: "Ctrl+X" is reserved for editing text`},
+		},
+		{
+			name:     "key bound twice",
+			keymap:   map[string]string{ActionToggleLive: "F5", ActionUpdateLive: "f5"},
+			want:     map[Key]string{{Name: fyne.KeyF5}: ActionToggleLive},
+			problems: []string{"keymap.update_live: F5 is already bound to toggle_live"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problems := ParseKeymap(tt.keymap)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keys = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %q, want %q", problems, tt.problems)
+			}
+		})
+	}
+}
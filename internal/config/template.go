@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// template is the configuration file written when there is none.
+// It sets nothing but the version, and shows the defaults in comments.
+const template = `# Mr Verse configuration
+#
+# Command line flags and environment variables take precedence over these
+# settings. Changes are picked up while the application runs, except for
+# [paths], which take effect at the next start.
+
+version = 1
+
+[paths]
+# Where files are kept. Relative paths are relative to this file's folder.
+# home = "~/.local/share/mr-verse"
+# database = "bible.db"
+# translations = "translations"
+# logs = "logs"
+# media = "media"
+
+[bible]
+# The translation selected at startup, instead of the default set in the
+# Translations tab
+# default_translation = "KJV"
+# The reference in the search box at startup
+# startup_verse = "John 3:16"
+
+[network]
+# The address of the web stage display and remote API; leave empty to turn
+# it off. Use ":8080" to reach it from other devices on the network.
+# http_addr = "127.0.0.1:8080"
+# The token other devices must send to control follow-along mode and the timer;
+# leave empty to turn remote control off
+# token = ""
+
+[logging]
+# debug, info, warn or error
+# level = "info"
+# text or json
+# format = "text"
+
+[live_window]
+# The position and size of the live window, instead of the monitor chosen
+# in the settings
+# x = 1920
+# y = 0
+# width = 1920
+# height = 1080
+
+# Slide themes, in addition to the files of the themes folder. Colors a theme
+# leaves out come from the default theme.
+# [themes.Evening]
+# background = "#1a1a2e"
+# foreground = "#eeeeee"
+# words_of_christ = "#ff8a80"
+
+[keymap]
+# Keys for controller actions: a key such as "PageDown", "F5" or "L",
+# optionally after Ctrl, Alt, Shift or Super joined with "+".
+# An empty key unbinds the action.
+# next_verse = "PageDown"
+# previous_verse = "PageUp"
+# back = "Alt+Left"
+# forward = "Alt+Right"
+# toggle_live = "F5"
+# update_live = "F6"
+# focus_search = "Ctrl+L"
+`
+
+// WriteDefault writes a configuration file with the defaults in comments at path,
+// unless a file is already there. It reports whether it wrote the file.
+func WriteDefault(path string) (bool, error) {
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create configuration folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(template), 0644); err != nil {
+		return false, fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return true, nil
+}
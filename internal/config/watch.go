@@ -0,0 +1,83 @@
+package config
+
+import (
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay is how long changes must settle before the configuration file
+// is reloaded, since editors often write a file in several steps
+const reloadDelay = 300 * time.Millisecond
+
+// Reload is the configuration in use after the configuration file changed,
+// or the error when it is invalid and the configuration in use was kept
+type Reload struct {
+	File *File
+	Err  error
+}
+
+// Watch reloads the configuration file at path whenever it changes, and sends
+// the result on the returned channel until watching stops.
+// The file's folder is watched, so the file may be created, replaced or
+// renamed into place. The returned function stops watching; nothing is sent
+// once it returns, and the channel is closed.
+func Watch(path string) (<-chan Reload, func() error, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, nil, err
+	}
+
+	reloads := make(chan Reload)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		defer close(reloads)
+
+		timer := time.NewTimer(reloadDelay)
+		timer.Stop()
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) || event.Op == fsnotify.Chmod {
+					continue
+				}
+				timer.Reset(reloadDelay)
+			case <-timer.C:
+				f, err := reload(path)
+				select {
+				case reloads <- Reload{File: f, Err: err}:
+				case <-done:
+					return
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("Error watching configuration file", "file", path, "error", err)
+			}
+		}
+	}()
+
+	stop := func() error {
+		close(done)
+		err := watcher.Close()
+		<-stopped
+		return err
+	}
+	return reloads, stop, nil
+}
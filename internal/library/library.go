@@ -0,0 +1,134 @@
+// Package library stores reusable slides such as sermon points and announcements
+package library
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/schema"
+)
+
+// DB is the database connection used by the library
+var DB *sql.DB
+
+// Init sets the database connection and creates the library tables if they don't exist
+func Init(db *sql.DB) error {
+	DB = db
+
+	_, err := DB.Exec(`
+		CREATE TABLE IF NOT EXISTS slides (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			body TEXT NOT NULL,
+			footer TEXT NOT NULL DEFAULT '',
+			theme TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Columns added after the first release
+	columns := []struct{ name, definition string }{
+		{"background", "TEXT NOT NULL DEFAULT ''"},
+		{"background_mode", "TEXT NOT NULL DEFAULT ''"},
+		{"background_dim", "REAL NOT NULL DEFAULT 0"},
+	}
+	for _, column := range columns {
+		if err := schema.AddColumn(DB, "slides", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSlides returns all saved slides, most recently updated first
+func GetSlides() ([]*presentation.Slide, error) {
+	rows, err := DB.Query(`
+		SELECT id, title, body, footer, theme, background, background_mode, background_dim
+		FROM slides
+		ORDER BY updated_at DESC, id DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slides []*presentation.Slide
+	for rows.Next() {
+		var s presentation.Slide
+		if err := scanSlide(rows, &s); err != nil {
+			return nil, err
+		}
+		slides = append(slides, &s)
+	}
+
+	return slides, rows.Err()
+}
+
+// GetSlide returns a saved slide by ID
+func GetSlide(id int) (*presentation.Slide, error) {
+	var s presentation.Slide
+	err := scanSlide(DB.QueryRow(`
+		SELECT id, title, body, footer, theme, background, background_mode, background_dim
+		FROM slides
+		WHERE id = ?
+	`, id), &s)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("slide not found: %d", id)
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveSlide inserts a new slide, or updates it if it already has an ID.
+// The slide's ID is set after inserting.
+func SaveSlide(slide *presentation.Slide) error {
+	if slide.Title == "" && slide.Body == "" {
+		return fmt.Errorf("slide has no title or body")
+	}
+
+	if slide.ID > 0 {
+		_, err := DB.Exec(`
+			UPDATE slides
+			SET title = ?, body = ?, footer = ?, theme = ?,
+				background = ?, background_mode = ?, background_dim = ?,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, slide.Title, slide.Body, slide.Footer, slide.Theme,
+			slide.Background, slide.BackgroundMode, slide.BackgroundDim, slide.ID)
+		return err
+	}
+
+	result, err := DB.Exec(`
+		INSERT INTO slides (title, body, footer, theme, background, background_mode, background_dim)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, slide.Title, slide.Body, slide.Footer, slide.Theme,
+		slide.Background, slide.BackgroundMode, slide.BackgroundDim)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	slide.ID = int(id)
+	return nil
+}
+
+// scanSlide scans a slides row selected with the columns used by GetSlides
+func scanSlide(row interface{ Scan(...any) error }, s *presentation.Slide) error {
+	return row.Scan(&s.ID, &s.Title, &s.Body, &s.Footer, &s.Theme,
+		&s.Background, &s.BackgroundMode, &s.BackgroundDim)
+}
+
+// DeleteSlide removes a slide from the library
+func DeleteSlide(id int) error {
+	_, err := DB.Exec("DELETE FROM slides WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,162 @@
+// Package logging sets up the application log: leveled, structured records
+// written to the console and to a log file that is rotated by size and age
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported log formats
+var Formats = []string{FormatText, FormatJSON}
+
+// fileName is the name of the current log file
+const fileName = "mr-verse.log"
+
+// Options configure the application log
+type Options struct {
+	Level      slog.Level
+	Format     string
+	Console    io.Writer     // Where records are echoed, or nil
+	Dir        string        // Folder of the log files, or "" for no file
+	MaxSize    int64         // Size in bytes at which the log file is rotated
+	MaxAge     time.Duration // Rotated files older than this are removed
+	MaxBackups int           // Most rotated files kept
+}
+
+// DefaultOptions returns the options used unless configured otherwise
+func DefaultOptions() Options {
+	return Options{
+		Level:      slog.LevelInfo,
+		Format:     FormatText,
+		Console:    os.Stdout,
+		MaxSize:    10 << 20,
+		MaxAge:     30 * 24 * time.Hour,
+		MaxBackups: 10,
+	}
+}
+
+// current is the log file written by the default logger, if any
+var current *rotatingFile
+
+// level is the level of the default logger, which can change while it runs
+var level slog.LevelVar
+
+// Setup makes a logger with the options the default logger of slog and of
+// the log package, and returns a function that closes the log file.
+// Records are still written to the console after the file is closed.
+func Setup(o Options) (func() error, error) {
+	var writers []io.Writer
+	if o.Console != nil {
+		writers = append(writers, o.Console)
+	}
+
+	var file *rotatingFile
+	if o.Dir != "" {
+		var err error
+		file, err = openRotatingFile(filepath.Join(o.Dir, fileName), o.MaxSize, o.MaxAge, o.MaxBackups, o.Console)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, file)
+	}
+
+	level.Set(o.Level)
+	handlerOptions := &slog.HandlerOptions{Level: &level}
+	var handler slog.Handler
+	switch o.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), handlerOptions)
+	case FormatText, "":
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), handlerOptions)
+	default:
+		if file != nil {
+			file.Close()
+		}
+		return nil, fmt.Errorf("unsupported log format: %s", o.Format)
+	}
+
+	// Records of the log package go through the handler too, at info level
+	slog.SetDefault(slog.New(handler))
+	current = file
+
+	return func() error {
+		if file == nil {
+			return nil
+		}
+		return file.Close()
+	}, nil
+}
+
+// SetLevel changes the level of the logger made by Setup
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Level returns the level of the logger made by Setup
+func Level() slog.Level {
+	return level.Level()
+}
+
+// ParseLevel parses a level name: "debug", "info", "warn" or "error"
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("invalid log level: %s", s)
+	}
+	return level, nil
+}
+
+// File returns the path of the current log file, or "" when the log isn't written to a file
+func File() string {
+	if current == nil {
+		return ""
+	}
+	return current.path
+}
+
+// Tail returns up to the last n lines of the current log file
+func Tail(n int) ([]string, error) {
+	if current == nil {
+		return nil, nil
+	}
+	data, err := current.tail(64 << 10)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 64<<10 && len(lines) > 1 {
+		lines = lines[1:] // The first line is cut off
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+// Translation is the contextual field of the translation a record is about
+func Translation(translation string) slog.Attr {
+	return slog.String("translation", translation)
+}
+
+// Reference is the contextual field of the verse a record is about, e.g. "John 3:16"
+func Reference(book string, chapter, verse int) slog.Attr {
+	return slog.String("reference", fmt.Sprintf("%s %d:%d", book, chapter, verse))
+}
+
+// Action is the contextual field of what the operator did, e.g. "search" or "import"
+func Action(action string) slog.Attr {
+	return slog.String("action", action)
+}
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp in the names of rotated log files
+const backupTimeFormat = "20060102-150405.000"
+
+// rotatingFile is a log file that is moved aside when it grows too large,
+// keeping a limited number of recent rotated files
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	console    io.Writer // Where problems with the file are reported, if anywhere
+	file       *os.File
+	size       int64
+	rotateAt   int64 // Size at which the file is rotated next
+	closed     bool
+	failing    bool // A problem was reported and the file hasn't been rotated since
+}
+
+// openRotatingFile opens the log file at path for appending, creating its folder.
+// Problems rotating the file are reported to console.
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int, console io.Writer) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f := &rotatingFile{path: path, maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups, console: console, rotateAt: maxSize}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.removeBackups()
+	return f, nil
+}
+
+// Write writes a record, rotating the file first if the record would make it too large.
+// When the file can't be rotated, records are appended to it until it grows by
+// another maxSize, and rotating is tried again.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.rotateAt {
+		f.rotate()
+	}
+	if f.file == nil {
+		// Reopening after a rotation failed; try again with each record
+		if err := f.open(); err != nil {
+			f.report("Failed to reopen log file; records are only written to the console", err)
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open opens the log file for appending
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// rotate moves the log file aside with the time in its name and starts a new one.
+// When the file can't be moved, e.g. while another program has it open on
+// Windows, it is reopened to write on to it. The file is left closed when it
+// can't be reopened, and Write tries again.
+func (f *rotatingFile) rotate() {
+	// Windows can't move an open file
+	f.file.Close()
+	f.file = nil
+
+	ext := filepath.Ext(f.path)
+	backup := strings.TrimSuffix(f.path, ext) + "-" + time.Now().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		f.report("Failed to rotate log file; writing on to it", err)
+		f.open()
+		f.rotateAt = f.size + f.maxSize
+		return
+	}
+
+	f.failing = false
+	f.rotateAt = f.maxSize
+	if f.open() == nil {
+		f.removeBackups()
+	}
+}
+
+// report writes a problem with the log file to the console, once until the
+// file is rotated again. It can't use the logger, which writes to the file.
+func (f *rotatingFile) report(msg string, err error) {
+	if f.failing || f.console == nil {
+		return
+	}
+	f.failing = true
+	slog.New(slog.NewTextHandler(f.console, nil)).Warn(msg, "file", f.path, "error", err)
+}
+
+// removeBackups removes rotated files that are too old or too many
+func (f *rotatingFile) removeBackups() {
+	ext := filepath.Ext(f.path)
+	backups, err := filepath.Glob(strings.TrimSuffix(f.path, ext) + "-*" + ext)
+	if err != nil {
+		return
+	}
+
+	// Newest first; the timestamps in the names sort by time
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	for i, backup := range backups {
+		remove := f.maxBackups > 0 && i >= f.maxBackups
+		if info, err := os.Stat(backup); err == nil && f.maxAge > 0 && time.Since(info.ModTime()) > f.maxAge {
+			remove = true
+		}
+		if remove {
+			os.Remove(backup)
+		}
+	}
+}
+
+// tail returns up to the last max bytes of the log file
+func (f *rotatingFile) tail(max int64) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	offset := f.size - max
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(file)
+}
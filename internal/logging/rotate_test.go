@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mr-verse.log")
+	f, err := openRotatingFile(path, 100, 0, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	record := []byte(strings.Repeat("x", 39) + "\n")
+	for range 10 {
+		if _, err := f.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "mr-verse-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("%d rotated files, want 2", len(backups))
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() > 100 {
+		t.Errorf("log file is %v, want at most 100 bytes", info)
+	}
+}
+
+func TestRotatingFileRotateFails(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mr-verse.log")
+	var console bytes.Buffer
+	f, err := openRotatingFile(path, 100, 0, 2, &console)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// Moving the file aside fails once it is gone
+	record := []byte(strings.Repeat("x", 39) + "\n")
+	f.Write(record)
+	f.Write(record)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 2 {
+		if _, err := f.Write(record); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2*len(record) {
+		t.Errorf("log file has %d bytes, want the %d written after the failure", len(data), 2*len(record))
+	}
+	if n := strings.Count(console.String(), "Failed to rotate log file"); n != 1 {
+		t.Errorf("failure reported %d times, want once:\n%s", n, console.String())
+	}
+
+	f.Close()
+	if _, err := f.Write(record); err != os.ErrClosed {
+		t.Errorf("Write after Close = %v, want %v", err, os.ErrClosed)
+	}
+}
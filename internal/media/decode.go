@@ -0,0 +1,198 @@
+package media
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"time"
+)
+
+// defaultFrameDelay is used for GIF frames without a delay
+const defaultFrameDelay = 100 * time.Millisecond
+
+// Media is a decoded background: a single image or a motion loop. Motion loops
+// keep the GIF's paletted frames, which a Player composes one at a time, since
+// composing every frame up front takes gigabytes for a long full HD loop.
+type Media struct {
+	still image.Image
+	anim  *gif.GIF
+}
+
+// IsMotion returns whether the media has more than one frame
+func (m *Media) IsMotion() bool {
+	return m.anim != nil && len(m.anim.Image) > 1
+}
+
+// Frame returns the image, or the first frame of a motion loop
+func (m *Media) Frame() image.Image {
+	if m.anim == nil {
+		return m.still
+	}
+	frame, _ := m.Play().Next()
+	return frame
+}
+
+// Load decodes a file from the media library
+func Load(name string) (*Media, error) {
+	kind, ok := KindOf(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported media file: %s", name)
+	}
+
+	file, err := os.Open(Path(name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if kind == KindMotion {
+		all, err := gif.DecodeAll(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		if len(all.Image) == 0 {
+			return nil, fmt.Errorf("failed to decode %s: no frames", name)
+		}
+		return &Media{anim: all}, nil
+	}
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return &Media{still: img}, nil
+}
+
+// Player composes the frames of a motion loop in order, applying each frame's
+// disposal method. It draws into two buffers in turn, so a frame stays intact
+// while the next one is composed.
+type Player struct {
+	anim    *gif.GIF
+	bounds  image.Rectangle
+	next    int         // Index of the next frame
+	current *image.RGBA // The loop composed up to the next frame
+	saved   *image.RGBA // The composition restored after a DisposalPrevious frame
+	buffers [2]*image.RGBA
+	buffer  int // Index of the buffer the next frame is drawn into
+}
+
+// Play returns a player of the media's frames from the first one.
+// A single image plays as one frame.
+func (m *Media) Play() *Player {
+	anim := m.anim
+	if anim == nil {
+		anim = &gif.GIF{}
+	}
+	bounds := image.Rect(0, 0, anim.Config.Width, anim.Config.Height)
+	if bounds.Empty() && len(anim.Image) > 0 {
+		bounds = anim.Image[0].Bounds()
+	}
+	return &Player{
+		anim:    anim,
+		bounds:  bounds,
+		current: image.NewRGBA(bounds),
+	}
+}
+
+// Next composes the next frame, looping after the last one, and returns it
+// with how long it is shown. The frame is overwritten two calls later.
+func (p *Player) Next() (image.Image, time.Duration) {
+	if len(p.anim.Image) == 0 {
+		return p.current, 0
+	}
+
+	i := p.next
+	frame := p.anim.Image[i]
+	disposal := byte(0)
+	if i < len(p.anim.Disposal) {
+		disposal = p.anim.Disposal[i]
+	}
+	if i == 0 {
+		draw.Draw(p.current, p.bounds, image.Transparent, image.Point{}, draw.Src)
+	}
+	if disposal == gif.DisposalPrevious {
+		if p.saved == nil {
+			p.saved = image.NewRGBA(p.bounds)
+		}
+		draw.Draw(p.saved, p.bounds, p.current, p.bounds.Min, draw.Src)
+	}
+
+	draw.Draw(p.current, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+	out := p.buffers[p.buffer]
+	if out == nil {
+		out = image.NewRGBA(p.bounds)
+		p.buffers[p.buffer] = out
+	}
+	draw.Draw(out, p.bounds, p.current, p.bounds.Min, draw.Src)
+	p.buffer = 1 - p.buffer
+
+	switch disposal {
+	case gif.DisposalPrevious:
+		p.current, p.saved = p.saved, p.current
+	case gif.DisposalBackground:
+		draw.Draw(p.current, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	}
+
+	delay := defaultFrameDelay
+	if i < len(p.anim.Delay) && p.anim.Delay[i] > 0 {
+		delay = time.Duration(p.anim.Delay[i]) * 10 * time.Millisecond
+	}
+	p.next = (i + 1) % len(p.anim.Image)
+	return out, delay
+}
+
+// Cover crops an image to the shape of a width by height area, keeping the center,
+// so stretching it fills the area without distortion
+func Cover(img image.Image, width, height float32) image.Image {
+	b := img.Bounds()
+	if width <= 0 || height <= 0 || b.Empty() {
+		return img
+	}
+
+	crop := b
+	if float32(b.Dx())/float32(b.Dy()) > width/height {
+		w := int(float32(b.Dy()) * width / height)
+		crop.Min.X = b.Min.X + (b.Dx()-w)/2
+		crop.Max.X = crop.Min.X + w
+	} else {
+		h := int(float32(b.Dx()) * height / width)
+		crop.Min.Y = b.Min.Y + (b.Dy()-h)/2
+		crop.Max.Y = crop.Min.Y + h
+	}
+
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(crop)
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, crop.Min, draw.Src)
+	return cropped
+}
+
+// Tile repeats an image across a width by height area. It draws into dst when
+// dst has that size, and into a new image otherwise, and returns the image.
+func Tile(dst *image.RGBA, img image.Image, width, height int) *image.RGBA {
+	b := img.Bounds()
+	area := image.Rect(0, 0, max(width, 1), max(height, 1))
+	tiled := dst
+	if tiled == nil || tiled.Bounds() != area {
+		tiled = image.NewRGBA(area)
+	}
+	if b.Empty() {
+		return tiled
+	}
+
+	for y := 0; y < height; y += b.Dy() {
+		for x := 0; x < width; x += b.Dx() {
+			draw.Draw(tiled, image.Rect(x, y, x+b.Dx(), y+b.Dy()), img, b.Min, draw.Src)
+		}
+	}
+	return tiled
+}
@@ -0,0 +1,94 @@
+// Package media manages the media library folder of background images
+// and looping motion backgrounds
+package media
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+)
+
+// Background scaling modes
+const (
+	ModeFill = "fill" // Cover the whole screen, cropping the edges
+	ModeFit  = "fit"  // Show the whole image, with bars if the shape differs
+	ModeTile = "tile" // Repeat the image at its own size
+)
+
+// Modes lists the background scaling modes
+var Modes = []string{ModeFill, ModeFit, ModeTile}
+
+// Media kinds
+const (
+	KindImage  = "image"
+	KindMotion = "motion" // Animated GIF, played in a loop
+)
+
+// extensionKinds maps the supported file extensions to their kind.
+// Video files need codecs that aren't available in a pure Go build,
+// so motion backgrounds are animated GIFs.
+var extensionKinds = map[string]string{
+	".png":  KindImage,
+	".jpg":  KindImage,
+	".jpeg": KindImage,
+	".gif":  KindMotion,
+}
+
+// Background is a media library file shown behind slides
+type Background struct {
+	File string  // File name in the media folder
+	Mode string  // One of Modes
+	Dim  float64 // Darkening from 0 (none) to 1 (black)
+}
+
+// Item is a file in the media library
+type Item struct {
+	Name string
+	Kind string
+}
+
+// GetDir returns the media library folder
+func GetDir() string {
+	return appdir.MediaDir()
+}
+
+// Path returns the path of a file in the media library
+func Path(name string) string {
+	return filepath.Join(GetDir(), filepath.Base(name))
+}
+
+// KindOf returns the kind of a media file by its extension
+func KindOf(name string) (string, bool) {
+	kind, ok := extensionKinds[strings.ToLower(filepath.Ext(name))]
+	return kind, ok
+}
+
+// List returns the supported files in the media library, sorted by name.
+// A missing folder is an empty library.
+func List() ([]Item, error) {
+	entries, err := os.ReadDir(GetDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var items []Item
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if kind, ok := KindOf(entry.Name()); ok {
+			items = append(items, Item{Name: entry.Name(), Kind: kind})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+	})
+	return items, nil
+}
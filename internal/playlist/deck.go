@@ -0,0 +1,96 @@
+package playlist
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// maxSlideChars is the most verse text put on one slide before a passage
+// continues on the next slide
+const maxSlideChars = 350
+
+// Layout of deck slides in points, 16:9 like the live window
+const (
+	slideWidth  = 960
+	slideHeight = 540
+	slideMargin = 36
+	titleTop    = 24
+	titleHeight = 64
+	bodyTop     = 100
+	bodyHeight  = 416
+	titleSize   = 32
+)
+
+// bodySizes are the font sizes tried for slide text, largest first
+var bodySizes = []int{48, 44, 40, 36, 32, 28, 24, 20, 18}
+
+// deckSlide is one slide of a deck
+type deckSlide struct {
+	Title    string
+	Runs     []run
+	FontSize int // In points
+}
+
+// deckSlides splits the passages of a playlist into slides.
+// Long passages continue on further slides at verse boundaries.
+func deckSlides(items []*Item) []deckSlide {
+	var slides []deckSlide
+	for _, item := range items {
+		numbered := len(item.Verses) > 1
+		start, chars := 0, 0
+		for i, verse := range item.Verses {
+			length := len([]rune(verse.Text))
+			if i > start && chars+length > maxSlideChars {
+				slides = append(slides, newDeckSlide(item, start, i, numbered))
+				start, chars = i, 0
+			}
+			chars += length
+		}
+		slides = append(slides, newDeckSlide(item, start, len(item.Verses), numbered))
+	}
+	return slides
+}
+
+// newDeckSlide creates the slide for the verses from start to end of a passage
+func newDeckSlide(item *Item, start, end int, numbered bool) deckSlide {
+	title := item.Title
+	if start > 0 || end < len(item.Verses) {
+		title = presentation.FormatPassage(item.Verses[start:end])
+	}
+	runs := verseRuns(item.Verses[start:end], numbered)
+	return deckSlide{
+		Title:    title,
+		Runs:     runs,
+		FontSize: fitFontSize(runsLength(runs), slideWidth-2*slideMargin, bodyHeight),
+	}
+}
+
+// fitFontSize returns the largest body size at which text of the given length
+// fits in a box, estimating the width of bold text at 0.55em per character
+func fitFontSize(chars int, width, height float64) int {
+	for _, size := range bodySizes {
+		perLine := math.Floor(width / (float64(size) * 0.55))
+		lines := math.Ceil(float64(chars) / perLine)
+		if lines*float64(size)*1.2 <= height {
+			return size
+		}
+	}
+	return bodySizes[len(bodySizes)-1]
+}
+
+// hexColor returns a color as hex digits, e.g. "FF5252"
+func hexColor(c color.Color) string {
+	r, g, b, _ := color.NRGBAModel.Convert(c).RGBA()
+	return fmt.Sprintf("%02X%02X%02X", r>>8, g>>8, b>>8)
+}
+
+// runColor returns the color of a run in a theme
+func runColor(r run, theme presentation.Theme) color.Color {
+	if r.Red {
+		return theme.WordsOfChrist
+	}
+	return theme.Foreground
+}
@@ -0,0 +1,115 @@
+package playlist
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/xmltext"
+)
+
+// odpMimeType is the media type of OpenDocument presentations
+const odpMimeType = "application/vnd.oasis.opendocument.presentation"
+
+// Namespaces of OpenDocument files
+const odfNamespaces = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" ` +
+	`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+	`xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" ` +
+	`xmlns:presentation="urn:oasis:names:tc:opendocument:xmlns:presentation:1.0" ` +
+	`office:version="1.2"`
+
+// ExportODP writes the playlist as an OpenDocument presentation with one slide per passage
+func ExportODP(w io.Writer, items []*Item, theme presentation.Theme) error {
+	z := zip.NewWriter(w)
+
+	// The media type comes first and uncompressed so the file type can be detected
+	f, err := z.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, odpMimeType); err != nil {
+		return err
+	}
+
+	files := []struct{ name, content string }{
+		{"META-INF/manifest.xml", `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+			`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odpMimeType + `"/>` +
+			`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+			`<manifest:file-entry manifest:full-path="styles.xml" manifest:media-type="text/xml"/>` +
+			`</manifest:manifest>`},
+		{"styles.xml", `<office:document-styles ` + odfNamespaces + `>` +
+			`<office:styles><style:style style:name="Background" style:family="drawing-page">` +
+			`<style:drawing-page-properties draw:fill="solid" draw:fill-color="#` + hexColor(theme.Background) + `"/></style:style></office:styles>` +
+			`<office:automatic-styles><style:page-layout style:name="PM1">` +
+			fmt.Sprintf(`<style:page-layout-properties fo:margin-top="0pt" fo:margin-bottom="0pt" fo:margin-left="0pt" fo:margin-right="0pt" `+
+				`fo:page-width="%dpt" fo:page-height="%dpt" style:print-orientation="landscape"/>`, slideWidth, slideHeight) +
+			`</style:page-layout></office:automatic-styles>` +
+			`<office:master-styles><style:master-page style:name="Default" style:page-layout-name="PM1" draw:style-name="Background"/></office:master-styles>` +
+			`</office:document-styles>`},
+		{"content.xml", odpContent(deckSlides(items), theme)},
+	}
+	for _, file := range files {
+		f, err := z.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xmlHeader+file.content); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
+
+// odpContent returns the content of a presentation: its slides and the text styles they use
+func odpContent(slides []deckSlide, theme presentation.Theme) string {
+	var pages strings.Builder
+	textStyles := map[string]string{} // Style name by properties
+	var styles strings.Builder
+
+	// textStyle returns the name of the text style for a run, adding it when new
+	textStyle := func(r run, size int) string {
+		properties := fmt.Sprintf(`fo:color="#%s" fo:font-family="Arial" fo:font-size="%dpt" fo:font-weight="bold"`, hexColor(runColor(r, theme)), size)
+		if r.Italic {
+			properties += ` fo:font-style="italic"`
+		}
+		if name, ok := textStyles[properties]; ok {
+			return name
+		}
+		name := fmt.Sprintf("T%d", len(textStyles)+1)
+		textStyles[properties] = name
+		fmt.Fprintf(&styles, `<style:style style:name="%s" style:family="text"><style:text-properties %s/></style:style>`, name, properties)
+		return name
+	}
+
+	// frame writes a centered text box across the slide
+	frame := func(top, height float64, runs []run, size int) {
+		fmt.Fprintf(&pages, `<draw:frame draw:style-name="gr1" svg:x="%dpt" svg:y="%gpt" svg:width="%dpt" svg:height="%gpt"><draw:text-box><text:p text:style-name="P1">`,
+			slideMargin, top, slideWidth-2*slideMargin, height)
+		for _, r := range runs {
+			fmt.Fprintf(&pages, `<text:span text:style-name="%s">%s</text:span>`, textStyle(r, size), xmltext.Escape(r.Text))
+		}
+		pages.WriteString(`</text:p></draw:text-box></draw:frame>`)
+	}
+
+	for i, slide := range slides {
+		fmt.Fprintf(&pages, `<draw:page draw:name="Slide %d" draw:style-name="dp1" draw:master-page-name="Default">`, i+1)
+		frame(titleTop, titleHeight, []run{{Text: slide.Title}}, titleSize)
+		frame(bodyTop, bodyHeight, slide.Runs, slide.FontSize)
+		pages.WriteString(`</draw:page>`)
+	}
+
+	return `<office:document-content ` + odfNamespaces + `><office:automatic-styles>` +
+		`<style:style style:name="dp1" style:family="drawing-page"><style:drawing-page-properties draw:fill="solid" draw:fill-color="#` + hexColor(theme.Background) + `" ` +
+		`presentation:background-visible="true" presentation:background-objects-visible="true"/></style:style>` +
+		`<style:style style:name="gr1" style:family="graphic"><style:graphic-properties draw:stroke="none" draw:fill="none" ` +
+		`draw:textarea-vertical-align="middle" draw:auto-grow-height="false" fo:padding="7pt"/></style:style>` +
+		`<style:style style:name="P1" style:family="paragraph"><style:paragraph-properties fo:text-align="center"/></style:style>` +
+		styles.String() +
+		`</office:automatic-styles><office:body><office:presentation>` + pages.String() +
+		`</office:presentation></office:body></office:document-content>`
+}
@@ -0,0 +1,361 @@
+package playlist
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layout of handout pages in points, US Letter
+const (
+	pageWidth    = 612
+	pageHeight   = 792
+	pageMargin   = 54
+	footerBottom = 30
+)
+
+// Handout fonts, all standard PDF fonts so nothing needs to be embedded
+const (
+	fontRegular = iota
+	fontBold
+	fontItalic
+)
+
+// pdfFontNames are the PDF names of the handout fonts
+var pdfFontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+// Character widths of Helvetica and Helvetica-Bold in thousandths of the font size,
+// from space (32) to tilde (126). Helvetica-Oblique has the widths of Helvetica.
+var (
+	helveticaWidths = []int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// winAnsi maps the characters of WinAnsiEncoding outside Latin-1 to their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// piece is text of a handout line in one font
+type piece struct {
+	text string
+	font int
+	size float64
+	rise float64 // Raised above the baseline, for verse numbers
+	red  bool
+}
+
+// handout lays out the text of a handout on pages
+type handout struct {
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the next line on the current page
+}
+
+// ExportPDF writes a printable handout with the full text of each passage of the playlist.
+// The handout fonts only have Western European characters, so passages with other
+// characters, e.g. Greek or Cyrillic, give an error rather than a garbled handout.
+func ExportPDF(w io.Writer, items []*Item) error {
+	for _, item := range items {
+		if err := checkEncodable(item); err != nil {
+			return err
+		}
+	}
+
+	h := &handout{}
+	h.newPage()
+
+	for i, item := range items {
+		if i > 0 {
+			h.y -= 12
+		}
+
+		// Keep the reference with the first line of the passage
+		needed := 18.0 + 15
+		if item.Section != "" {
+			needed += 14
+		}
+		if h.y-needed < pageMargin {
+			h.newPage()
+		}
+
+		h.paragraph([]piece{{text: item.Title, font: fontBold, size: 13}}, 18)
+		if item.Section != "" {
+			h.paragraph([]piece{{text: item.Section, font: fontItalic, size: 10}}, 14)
+		}
+
+		var body []piece
+		for _, r := range verseRuns(item.Verses, len(item.Verses) > 1) {
+			p := piece{text: r.Text, font: fontRegular, size: 11, red: r.Red}
+			if r.Italic {
+				p.font = fontItalic
+			}
+			if r.Number {
+				p.font, p.size, p.rise = fontBold, 7, 3.5
+			}
+			body = append(body, p)
+		}
+		h.paragraph(body, 15)
+	}
+
+	return h.write(w)
+}
+
+// newPage starts a new page
+func (h *handout) newPage() {
+	h.pages = append(h.pages, &bytes.Buffer{})
+	h.y = pageHeight - pageMargin
+}
+
+// paragraph writes text wrapped to the width of the page, starting new pages as needed
+func (h *handout) paragraph(pieces []piece, leading float64) {
+	// Split into words, which can mix fonts, e.g. italics before a comma
+	var words [][]piece
+	var word []piece
+	for _, p := range pieces {
+		for i, part := range strings.Split(p.text, " ") {
+			if i > 0 && len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+			if part != "" {
+				q := p
+				q.text = part
+				word = append(word, q)
+			}
+		}
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+
+	maxWidth := float64(pageWidth - 2*pageMargin)
+	var line []piece
+	lineWidth := 0.0
+	for _, word := range words {
+		width := 0.0
+		for _, p := range word {
+			width += textWidth(p.text, p.font, p.size)
+		}
+		if len(line) > 0 {
+			// Words are separated by a space in the font of the word before
+			last := line[len(line)-1]
+			space := textWidth(" ", last.font, last.size)
+			if lineWidth+space+width > maxWidth {
+				h.line(line, leading)
+				line, lineWidth = nil, 0
+			} else {
+				line[len(line)-1].text += " "
+				lineWidth += space
+			}
+		}
+		line = append(line, word...)
+		lineWidth += width
+	}
+	if len(line) > 0 {
+		h.line(line, leading)
+	}
+}
+
+// line writes a line of text at the left margin
+func (h *handout) line(pieces []piece, leading float64) {
+	if h.y-leading < pageMargin {
+		h.newPage()
+	}
+	h.y -= leading
+
+	page := h.pages[len(h.pages)-1]
+	fmt.Fprintf(page, "BT %d %.2f Td\n", pageMargin, h.y)
+	for i := 0; i < len(pieces); {
+		// Show the words with the same formatting at once
+		p := pieces[i]
+		text := p.text
+		for i++; i < len(pieces) && pieces[i].font == p.font && pieces[i].size == p.size && pieces[i].rise == p.rise && pieces[i].red == p.red; i++ {
+			text += pieces[i].text
+		}
+
+		color := "0 0 0"
+		if p.red {
+			color = "0.72 0.11 0.11"
+		}
+		fmt.Fprintf(page, "/F%d %g Tf %g Ts %s rg (%s) Tj\n", p.font+1, p.size, p.rise, color, pdfString(text))
+	}
+	page.WriteString("ET\n")
+}
+
+// write writes the pages with page numbers as a PDF document
+func (h *handout) write(w io.Writer) error {
+	var out bytes.Buffer
+	var offsets []int
+
+	// object starts the next object and returns its number
+	object := func() int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n", len(offsets))
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Catalog, page tree and fonts come first; each page is followed by its content
+	firstPage := 3 + len(pdfFontNames)
+	kids := make([]string, len(h.pages))
+	for i := range h.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	object()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	object()
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(h.pages))
+	var fonts strings.Builder
+	for i, name := range pdfFontNames {
+		n := object()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\nendobj\n", name)
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, n)
+	}
+
+	for i, page := range h.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(h.pages))
+		x := (pageWidth - textWidth(footer, fontRegular, 9)) / 2
+		fmt.Fprintf(page, "BT /F1 9 Tf 0 Ts 0.4 0.4 0.4 rg %.2f %d Td (%s) Tj ET\n", x, footerBottom, footer)
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		n := object()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s>> >> /Contents %d 0 R >>\nendobj\n",
+			pageWidth, pageHeight, fonts.String(), n+1)
+		object()
+		fmt.Fprintf(&out, "<< /Length %d /Filter /FlateDecode >>\nstream\n", content.Len())
+		out.Write(content.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// textWidth returns the width of text in points
+func textWidth(text string, font int, size float64) float64 {
+	widths := helveticaWidths
+	if font == fontBold {
+		widths = helveticaBoldWidths
+	}
+
+	total := 0
+	for _, c := range winAnsiBytes(text) {
+		switch {
+		case c >= 32 && c <= 126:
+			total += widths[c-32]
+		case c == 0x85 || c == 0x97:
+			total += 1000 // Ellipsis and em dash
+		case c >= 0x91 && c <= 0x94:
+			total += widths['\''-32] + 100 // Curly quotes
+		case c == 0xcc || c == 0xcd || c == 0xce || c == 0xcf || c == 0xec || c == 0xed || c == 0xee || c == 0xef:
+			total += 278 // Accented i
+		case c >= 0xc0 && c <= 0xde:
+			total += 722 // Accented capitals
+		default:
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// checkEncodable returns an error naming the characters of a playlist item the handout fonts don't have
+func checkEncodable(item *Item) error {
+	texts := []string{item.Title, item.Section}
+	for _, verse := range item.Verses {
+		texts = append(texts, verse.Text)
+	}
+
+	var missing []string
+	seen := make(map[rune]bool)
+	for _, text := range texts {
+		for _, r := range text {
+			if _, ok := winAnsiByte(r); !ok && !seen[r] {
+				seen[r] = true
+				missing = append(missing, fmt.Sprintf("%q", r))
+			}
+		}
+	}
+	if len(missing) > 5 {
+		missing = append(missing[:5], fmt.Sprintf("%d more", len(missing)-5))
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s: the PDF handout can't show the characters %s; export a slide deck instead",
+			item.Title, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// winAnsiByte returns the WinAnsiEncoding code of a character, and whether the standard fonts have it
+func winAnsiByte(r rune) (byte, bool) {
+	switch {
+	case r >= 32 && r <= 126, r >= 0xa0 && r <= 0xff:
+		return byte(r), true
+	case winAnsi[r] != 0:
+		return winAnsi[r], true
+	case r == '\n' || r == '\t':
+		return ' ', true
+	}
+	return 0, false
+}
+
+// winAnsiBytes encodes text for the standard fonts. ExportPDF checks the text first,
+// so the "?" for characters the fonts don't have is never shown.
+func winAnsiBytes(text string) []byte {
+	b := make([]byte, 0, len(text))
+	for _, r := range text {
+		c, ok := winAnsiByte(r)
+		if !ok {
+			c = '?'
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+// pdfString escapes text for a PDF string literal
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, c := range winAnsiBytes(text) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+// Package playlist exports a prepared list of Bible passages as a slide deck
+// for PowerPoint or Impress, or as a printable PDF handout
+package playlist
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// Export formats
+const (
+	FormatPPTX = "pptx"
+	FormatODP  = "odp"
+	FormatPDF  = "pdf"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatPPTX, FormatODP, FormatPDF}
+
+// Item is a passage of a playlist
+type Item struct {
+	Reference string // As written in the list, e.g. "John 3:16-18"
+	Title     string // Localized reference, e.g. "JOHN 3:16-18 NLT"
+	Section   string // Section heading the passage starts in
+	Verses    []*bible.Verse
+}
+
+// ParseReferences returns the references of a playlist, one per line.
+// Blank lines and lines starting with # are skipped.
+func ParseReferences(text string) []string {
+	var references []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		references = append(references, line)
+	}
+	return references
+}
+
+// Load looks up the passages of references in a translation
+func Load(translation string, references []string) ([]*Item, error) {
+	if len(references) == 0 {
+		return nil, fmt.Errorf("the playlist has no references")
+	}
+
+	language, err := bible.GetTranslationLanguage(translation)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0, len(references))
+	for _, reference := range references {
+		book, chapter, first, last, err := bible.ParsePassageIn(reference, language)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reference, err)
+		}
+		verses, err := bible.GetPassage(translation, book, chapter, first, last)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reference, err)
+		}
+		items = append(items, &Item{
+			Reference: reference,
+			Title:     presentation.FormatPassage(verses),
+			Section:   presentation.SectionHeading(verses[0]),
+			Verses:    verses,
+		})
+	}
+	return items, nil
+}
+
+// Export writes the playlist in the given format. Decks use the colors of the
+// slide theme; the PDF handout is always printed dark on white.
+func Export(w io.Writer, format string, items []*Item, theme presentation.Theme) error {
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case FormatPPTX:
+		return ExportPPTX(w, items, theme)
+	case FormatODP:
+		return ExportODP(w, items, theme)
+	case FormatPDF:
+		return ExportPDF(w, items)
+	}
+	return fmt.Errorf("unsupported export format: %s", format)
+}
+
+// run is a piece of text with the same formatting
+type run struct {
+	Text   string
+	Number bool // Verse number
+	Red    bool // Words of Christ
+	Italic bool // Words supplied by the translators
+}
+
+// verseRuns returns the text of verses with their markup, numbered when numbered is set
+func verseRuns(verses []*bible.Verse, numbered bool) []run {
+	var runs []run
+	for i, verse := range verses {
+		if i > 0 {
+			runs = append(runs, run{Text: " "})
+		}
+		if numbered {
+			runs = append(runs, run{Text: strconv.Itoa(verse.Verse) + " ", Number: true})
+		}
+
+		start := len(runs)
+		for _, span := range verse.Spans {
+			if span.Kind == bible.SpanText {
+				runs = append(runs, run{Text: span.Text, Red: span.Red, Italic: span.Italic})
+			}
+		}
+		if len(runs) == start {
+			runs = append(runs, run{Text: verse.Text})
+		}
+	}
+	return tidyRuns(runs)
+}
+
+// tidyRuns collapses whitespace across runs, drops empty runs and joins runs with the same formatting
+func tidyRuns(runs []run) []run {
+	var tidy []run
+	spaced := true // Drop leading spaces at the start and after a space
+	for _, r := range runs {
+		text := strings.Join(strings.Fields(r.Text), " ")
+		if text != "" && strings.TrimLeft(r.Text, " \t\n") != r.Text {
+			text = " " + text
+		}
+		if strings.TrimRight(r.Text, " \t\n") != r.Text && !strings.HasSuffix(text, " ") {
+			text += " "
+		}
+		if spaced {
+			text = strings.TrimLeft(text, " ")
+		}
+		if text == "" {
+			continue
+		}
+		spaced = strings.HasSuffix(text, " ")
+
+		r.Text = text
+		if n := len(tidy); n > 0 && tidy[n-1].Number == r.Number && tidy[n-1].Red == r.Red && tidy[n-1].Italic == r.Italic {
+			tidy[n-1].Text += text
+			continue
+		}
+		tidy = append(tidy, r)
+	}
+	if n := len(tidy); n > 0 {
+		tidy[n-1].Text = strings.TrimRight(tidy[n-1].Text, " ")
+	}
+	return tidy
+}
+
+// runsLength returns the number of characters of runs
+func runsLength(runs []run) int {
+	n := 0
+	for _, r := range runs {
+		n += len([]rune(r.Text))
+	}
+	return n
+}
@@ -0,0 +1,155 @@
+package playlist
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/xmltext"
+)
+
+// emuPerPoint converts points to the English Metric Units of Office documents
+const emuPerPoint = 12700
+
+// Namespaces of PowerPoint documents
+const (
+	pptxNamespaces = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+		`xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+	relTypes = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/"
+)
+
+// pptxEmptyTree is the start of a slide's shape tree
+const pptxEmptyTree = `<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>` +
+	`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr>`
+
+// ExportPPTX writes the playlist as a PowerPoint deck with one slide per passage
+func ExportPPTX(w io.Writer, items []*Item, theme presentation.Theme) error {
+	slides := deckSlides(items)
+	z := zip.NewWriter(w)
+
+	var overrides, slideIDs, slideRels strings.Builder
+	for i := range slides {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/ppt/slides/slide%d.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slide+xml"/>`, n)
+		fmt.Fprintf(&slideIDs, `<p:sldId id="%d" r:id="rId%d"/>`, 255+n, n+2)
+		fmt.Fprintf(&slideRels, `<Relationship Id="rId%d" Type="%sslide" Target="slides/slide%d.xml"/>`, n+2, relTypes, n)
+	}
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/ppt/presentation.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"/>` +
+			`<Override PartName="/ppt/slideMasters/slideMaster1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"/>` +
+			`<Override PartName="/ppt/slideLayouts/slideLayout1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"/>` +
+			`<Override PartName="/ppt/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relTypes + `officeDocument" Target="ppt/presentation.xml"/></Relationships>`},
+		{"ppt/presentation.xml", `<p:presentation ` + pptxNamespaces + `>` +
+			`<p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst>` +
+			`<p:sldIdLst>` + slideIDs.String() + `</p:sldIdLst>` +
+			fmt.Sprintf(`<p:sldSz cx="%d" cy="%d"/>`, slideWidth*emuPerPoint, slideHeight*emuPerPoint) +
+			`<p:notesSz cx="6858000" cy="9144000"/></p:presentation>`},
+		{"ppt/_rels/presentation.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relTypes + `slideMaster" Target="slideMasters/slideMaster1.xml"/>` +
+			`<Relationship Id="rId2" Type="` + relTypes + `theme" Target="theme/theme1.xml"/>` +
+			slideRels.String() + `</Relationships>`},
+		{"ppt/slideMasters/slideMaster1.xml", `<p:sldMaster ` + pptxNamespaces + `>` +
+			`<p:cSld><p:bg><p:bgPr><a:solidFill><a:srgbClr val="` + hexColor(theme.Background) + `"/></a:solidFill><a:effectLst/></p:bgPr></p:bg>` +
+			`<p:spTree>` + pptxEmptyTree + `</p:spTree></p:cSld>` +
+			`<p:clrMap bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" ` +
+			`accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/>` +
+			`<p:sldLayoutIdLst><p:sldLayoutId id="2147483649" r:id="rId1"/></p:sldLayoutIdLst></p:sldMaster>`},
+		{"ppt/slideMasters/_rels/slideMaster1.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relTypes + `slideLayout" Target="../slideLayouts/slideLayout1.xml"/>` +
+			`<Relationship Id="rId2" Type="` + relTypes + `theme" Target="../theme/theme1.xml"/></Relationships>`},
+		{"ppt/slideLayouts/slideLayout1.xml", `<p:sldLayout ` + pptxNamespaces + ` type="blank" preserve="1">` +
+			`<p:cSld name="Blank"><p:spTree>` + pptxEmptyTree + `</p:spTree></p:cSld>` +
+			`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sldLayout>`},
+		{"ppt/slideLayouts/_rels/slideLayout1.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relTypes + `slideMaster" Target="../slideMasters/slideMaster1.xml"/></Relationships>`},
+		{"ppt/theme/theme1.xml", pptxTheme(theme)},
+	}
+	for i, slide := range slides {
+		files = append(files,
+			struct{ name, content string }{fmt.Sprintf("ppt/slides/slide%d.xml", i+1), pptxSlide(slide, theme)},
+			struct{ name, content string }{fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", i+1),
+				`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+					`<Relationship Id="rId1" Type="` + relTypes + `slideLayout" Target="../slideLayouts/slideLayout1.xml"/></Relationships>`},
+		)
+	}
+
+	for _, file := range files {
+		f, err := z.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xmlHeader+file.content); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
+
+// xmlHeader starts the XML files of decks
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// pptxSlide returns the XML of a slide with its reference above its text
+func pptxSlide(slide deckSlide, theme presentation.Theme) string {
+	var b strings.Builder
+	b.WriteString(`<p:sld ` + pptxNamespaces + `><p:cSld>`)
+	b.WriteString(`<p:bg><p:bgPr><a:solidFill><a:srgbClr val="` + hexColor(theme.Background) + `"/></a:solidFill><a:effectLst/></p:bgPr></p:bg>`)
+	b.WriteString(`<p:spTree>` + pptxEmptyTree)
+
+	title := []run{{Text: slide.Title}}
+	pptxTextBox(&b, 2, "Reference", titleTop, titleHeight, title, titleSize, theme)
+	pptxTextBox(&b, 3, "Text", bodyTop, bodyHeight, slide.Runs, slide.FontSize, theme)
+
+	b.WriteString(`</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`)
+	return b.String()
+}
+
+// pptxTextBox writes a centered, bold text box across the slide
+func pptxTextBox(b *strings.Builder, id int, name string, top, height float64, runs []run, size int, theme presentation.Theme) {
+	fmt.Fprintf(b, `<p:sp><p:nvSpPr><p:cNvPr id="%d" name="%s"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>`, id, name)
+	fmt.Fprintf(b, `<p:spPr><a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom><a:noFill/></p:spPr>`,
+		slideMargin*emuPerPoint, int(top*emuPerPoint), (slideWidth-2*slideMargin)*emuPerPoint, int(height*emuPerPoint))
+	b.WriteString(`<p:txBody><a:bodyPr wrap="square" anchor="ctr"><a:normAutofit/></a:bodyPr><a:lstStyle/><a:p><a:pPr algn="ctr"/>`)
+	for _, r := range runs {
+		italic := ""
+		if r.Italic {
+			italic = ` i="1"`
+		}
+		fmt.Fprintf(b, `<a:r><a:rPr lang="en-US" sz="%d" b="1"%s dirty="0"><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:latin typeface="Arial"/></a:rPr><a:t>%s</a:t></a:r>`,
+			size*100, italic, hexColor(runColor(r, theme)), xmltext.Escape(r.Text))
+	}
+	b.WriteString(`</a:p></p:txBody></p:sp>`)
+}
+
+// pptxTheme returns the Office theme of a deck with the slide theme's colors
+func pptxTheme(theme presentation.Theme) string {
+	bg, fg, red := hexColor(theme.Background), hexColor(theme.Foreground), hexColor(theme.WordsOfChrist)
+	solid := `<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`
+	line := `<a:ln w="9525"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill></a:ln>`
+	effect := `<a:effectStyle><a:effectLst/></a:effectStyle>`
+	font := `<a:latin typeface="Arial"/><a:ea typeface=""/><a:cs typeface=""/>`
+	return `<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Mr Verse">` +
+		`<a:themeElements><a:clrScheme name="Mr Verse">` +
+		`<a:dk1><a:srgbClr val="` + fg + `"/></a:dk1><a:lt1><a:srgbClr val="` + bg + `"/></a:lt1>` +
+		`<a:dk2><a:srgbClr val="` + fg + `"/></a:dk2><a:lt2><a:srgbClr val="` + bg + `"/></a:lt2>` +
+		`<a:accent1><a:srgbClr val="` + red + `"/></a:accent1><a:accent2><a:srgbClr val="4472C4"/></a:accent2>` +
+		`<a:accent3><a:srgbClr val="A5A5A5"/></a:accent3><a:accent4><a:srgbClr val="FFC000"/></a:accent4>` +
+		`<a:accent5><a:srgbClr val="5B9BD5"/></a:accent5><a:accent6><a:srgbClr val="70AD47"/></a:accent6>` +
+		`<a:hlink><a:srgbClr val="0563C1"/></a:hlink><a:folHlink><a:srgbClr val="954F72"/></a:folHlink></a:clrScheme>` +
+		`<a:fontScheme name="Mr Verse"><a:majorFont>` + font + `</a:majorFont><a:minorFont>` + font + `</a:minorFont></a:fontScheme>` +
+		`<a:fmtScheme name="Mr Verse">` +
+		`<a:fillStyleLst>` + solid + solid + solid + `</a:fillStyleLst>` +
+		`<a:lnStyleLst>` + line + line + line + `</a:lnStyleLst>` +
+		`<a:effectStyleLst>` + effect + effect + effect + `</a:effectStyleLst>` +
+		`<a:bgFillStyleLst>` + solid + solid + solid + `</a:bgFillStyleLst>` +
+		`</a:fmtScheme></a:themeElements><a:objectDefaults/><a:extraClrSchemeLst/></a:theme>`
+}
@@ -0,0 +1,250 @@
+package presentation
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+)
+
+// Auto-advance states
+const (
+	AutoAdvanceStopped = "stopped"
+	AutoAdvanceRunning = "running"
+	AutoAdvancePaused  = "paused"
+)
+
+// MinAutoAdvanceDelay is the shortest time a verse stays up when the
+// delay is based on reading speed
+const MinAutoAdvanceDelay = 2 * time.Second
+
+// AutoAdvanceSettings configures how follow-along mode advances
+type AutoAdvanceSettings struct {
+	Interval       time.Duration // Time per verse, used when WordsPerMinute is 0
+	WordsPerMinute int           // Reading speed used to time each verse by its word count
+	EndBook        string        // Last verse of the passage; empty to read on
+	EndChapter     int
+	EndVerse       int
+}
+
+// AutoAdvanceStatus is a snapshot of the follow-along state
+type AutoAdvanceStatus struct {
+	State     string
+	Remaining time.Duration // Time until the next verse
+	Settings  AutoAdvanceSettings
+}
+
+// AutoAdvance moves the presentation to the next verse after a delay,
+// for responsive readings. Showing a verse by hand restarts the delay.
+type AutoAdvance struct {
+	mu        sync.Mutex
+	vp        *VersePresentation
+	settings  AutoAdvanceSettings
+	state     string
+	timer     *time.Timer
+	deadline  time.Time
+	remaining time.Duration // Time left when paused
+	onChange  func(AutoAdvanceStatus)
+}
+
+// NewAutoAdvance creates a stopped follow-along mode for a presentation
+func NewAutoAdvance(vp *VersePresentation) *AutoAdvance {
+	a := &AutoAdvance{
+		vp:    vp,
+		state: AutoAdvanceStopped,
+	}
+	vp.AddObserver(a.slideChanged)
+	return a
+}
+
+// SetOnChange sets a callback for state changes, e.g. stopping at the end of the passage
+func (a *AutoAdvance) SetOnChange(onChange func(AutoAdvanceStatus)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onChange = onChange
+}
+
+// Start starts advancing from the current verse
+func (a *AutoAdvance) Start(settings AutoAdvanceSettings) error {
+	if settings.Interval <= 0 && settings.WordsPerMinute <= 0 {
+		return fmt.Errorf("an interval or reading speed is required")
+	}
+
+	slide := a.vp.GetSlide()
+	if slide == nil || !slide.IsVerse() {
+		return fmt.Errorf("no current verse to follow from")
+	}
+	if pastEnd(slide, settings) {
+		return fmt.Errorf("the passage ends before the current verse")
+	}
+
+	a.mu.Lock()
+	a.settings = settings
+	a.state = AutoAdvanceRunning
+	a.schedule(a.delay(slide))
+	a.mu.Unlock()
+
+	a.notify()
+	return nil
+}
+
+// Pause pauses advancing, keeping the time left for the current verse
+func (a *AutoAdvance) Pause() {
+	a.mu.Lock()
+	if a.state != AutoAdvanceRunning {
+		a.mu.Unlock()
+		return
+	}
+	a.timer.Stop()
+	a.remaining = max(time.Until(a.deadline), 0)
+	a.state = AutoAdvancePaused
+	a.mu.Unlock()
+
+	a.notify()
+}
+
+// Resume continues advancing after a pause
+func (a *AutoAdvance) Resume() {
+	a.mu.Lock()
+	if a.state != AutoAdvancePaused {
+		a.mu.Unlock()
+		return
+	}
+	a.state = AutoAdvanceRunning
+	a.schedule(a.remaining)
+	a.mu.Unlock()
+
+	a.notify()
+}
+
+// Stop stops advancing
+func (a *AutoAdvance) Stop() {
+	a.mu.Lock()
+	if a.state == AutoAdvanceStopped {
+		a.mu.Unlock()
+		return
+	}
+	a.stop()
+	a.mu.Unlock()
+
+	a.notify()
+}
+
+// Status returns the current follow-along state
+func (a *AutoAdvance) Status() AutoAdvanceStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status()
+}
+
+// status builds the status; the caller must hold the lock
+func (a *AutoAdvance) status() AutoAdvanceStatus {
+	status := AutoAdvanceStatus{
+		State:    a.state,
+		Settings: a.settings,
+	}
+	switch a.state {
+	case AutoAdvanceRunning:
+		status.Remaining = max(time.Until(a.deadline), 0)
+	case AutoAdvancePaused:
+		status.Remaining = a.remaining
+	}
+	return status
+}
+
+// schedule arms the timer; the caller must hold the lock
+func (a *AutoAdvance) schedule(delay time.Duration) {
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.deadline = time.Now().Add(delay)
+	a.timer = time.AfterFunc(delay, a.advance)
+}
+
+// stop stops the timer; the caller must hold the lock
+func (a *AutoAdvance) stop() {
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.state = AutoAdvanceStopped
+	a.remaining = 0
+}
+
+// delay returns how long a slide stays up before advancing
+func (a *AutoAdvance) delay(slide *Slide) time.Duration {
+	if a.settings.WordsPerMinute <= 0 {
+		return a.settings.Interval
+	}
+
+	words := len(strings.Fields(slide.Body))
+	delay := time.Duration(words) * time.Minute / time.Duration(a.settings.WordsPerMinute)
+	return max(delay, MinAutoAdvanceDelay)
+}
+
+// advance shows the next verse, or stops at the end of the passage
+func (a *AutoAdvance) advance() {
+	a.mu.Lock()
+	if a.state != AutoAdvanceRunning || time.Now().Before(a.deadline) {
+		a.mu.Unlock()
+		return // Paused, stopped or rescheduled meanwhile
+	}
+	settings := a.settings
+	a.mu.Unlock()
+
+	slide := a.vp.GetSlide()
+	if slide == nil || !slide.IsVerse() || atEnd(slide, settings) {
+		a.Stop()
+		return
+	}
+
+	// The slide observer schedules the next verse
+	if err := a.vp.FetchAndSetNextVerse(); err != nil {
+		a.Stop()
+	}
+}
+
+// slideChanged restarts the delay whenever a new slide is shown
+func (a *AutoAdvance) slideChanged(slide *Slide) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state != AutoAdvanceRunning || slide == nil {
+		return
+	}
+	a.schedule(a.delay(slide))
+}
+
+// notify calls the change callback with the current status
+func (a *AutoAdvance) notify() {
+	a.mu.Lock()
+	onChange := a.onChange
+	status := a.status()
+	a.mu.Unlock()
+
+	if onChange != nil {
+		onChange(status)
+	}
+}
+
+// atEnd reports whether a verse slide is the last verse of the passage or later
+func atEnd(slide *Slide, settings AutoAdvanceSettings) bool {
+	return settings.EndBook != "" && compareToEnd(slide.Verse, settings) >= 0
+}
+
+// pastEnd reports whether a verse slide comes after the end of the passage
+func pastEnd(slide *Slide, settings AutoAdvanceSettings) bool {
+	return settings.EndBook != "" && compareToEnd(slide.Verse, settings) > 0
+}
+
+// compareToEnd compares a verse to the end of the passage in Bible order, returning -1, 0 or +1
+func compareToEnd(v *bible.Verse, settings AutoAdvanceSettings) int {
+	if c := bible.CompareBooks(v.Book, settings.EndBook); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Chapter, settings.EndChapter); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.Verse, settings.EndVerse)
+}
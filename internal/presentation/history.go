@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is the number of slides kept in the presentation history
+const DefaultHistoryLimit = 100
+
+// HistoryEntry is a slide that was shown and when it was shown
+type HistoryEntry struct {
+	Slide   *Slide
+	ShownAt time.Time
+}
+
+// History is a bounded list of shown slides with back/forward navigation
+type History struct {
+	mu       sync.RWMutex
+	entries  []HistoryEntry
+	position int // Index of the entry currently shown, -1 when empty
+	limit    int
+}
+
+// NewHistory creates a history keeping at most limit entries
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{
+		entries:  make([]HistoryEntry, 0, limit),
+		position: -1,
+		limit:    limit,
+	}
+}
+
+// Record adds a newly shown slide to the history.
+// Like a web browser, entries after the current position are dropped.
+func (h *History) Record(slide *Slide) {
+	if slide == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries[:h.position+1], HistoryEntry{
+		Slide:   slide,
+		ShownAt: time.Now(),
+	})
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	h.position = len(h.entries) - 1
+}
+
+// Back moves to the previously shown slide.
+// Returns false when there is nothing to go back to.
+func (h *History) Back() (*Slide, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.position <= 0 {
+		return nil, false
+	}
+	h.position--
+	return h.entries[h.position].Slide, true
+}
+
+// Forward moves to the slide shown after the current one.
+// Returns false when there is nothing to go forward to.
+func (h *History) Forward() (*Slide, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.position >= len(h.entries)-1 {
+		return nil, false
+	}
+	h.position++
+	return h.entries[h.position].Slide, true
+}
+
+// Entries returns the history, most recently shown first
+func (h *History) Entries() []HistoryEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	entries := make([]HistoryEntry, len(h.entries))
+	for i, entry := range h.entries {
+		entries[len(h.entries)-1-i] = entry
+	}
+	return entries
+}
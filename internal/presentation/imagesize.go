@@ -0,0 +1,32 @@
+package presentation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ImageSizes are common sizes for exported images: widescreen slides,
+// square and portrait social media posts, and 4K
+var ImageSizes = []string{"1920x1080", "1080x1080", "1080x1350", "1080x1920", "1280x720", "3840x2160"}
+
+// maxImageSide is the largest width or height of an exported image
+const maxImageSide = 8192
+
+// ParseImageSize parses an image size such as "1920x1080"
+func ParseImageSize(size string) (width, height int, err error) {
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(size)), "x")
+	if ok {
+		width, err = strconv.Atoi(strings.TrimSpace(w))
+		if err == nil {
+			height, err = strconv.Atoi(strings.TrimSpace(h))
+		}
+	}
+	if !ok || err != nil || width < 1 || height < 1 {
+		return 0, 0, fmt.Errorf("invalid image size %q, expected WIDTHxHEIGHT such as 1920x1080", size)
+	}
+	if width > maxImageSide || height > maxImageSide {
+		return 0, 0, fmt.Errorf("image size %q is too large, at most %dx%d", size, maxImageSide, maxImageSide)
+	}
+	return width, height, nil
+}
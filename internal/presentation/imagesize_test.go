@@ -0,0 +1,40 @@
+package presentation
+
+import "testing"
+
+func TestParseImageSize(t *testing.T) {
+	tests := []struct {
+		size          string
+		width, height int
+		wantErr       bool
+	}{
+		{size: "1920x1080", width: 1920, height: 1080},
+		{size: " 1080 X 1350 ", width: 1080, height: 1350},
+		{size: "8192x8192", width: 8192, height: 8192},
+		{size: "", wantErr: true},
+		{size: "1920", wantErr: true},
+		{size: "1920x", wantErr: true},
+		{size: "widexhigh", wantErr: true},
+		{size: "0x1080", wantErr: true},
+		{size: "-1920x1080", wantErr: true},
+		{size: "8193x1080", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			width, height, err := ParseImageSize(tt.size)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseImageSize(%q) = %dx%d, want an error", tt.size, width, height)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if width != tt.width || height != tt.height {
+				t.Errorf("ParseImageSize(%q) = %dx%d, want %dx%d", tt.size, width, height, tt.width, tt.height)
+			}
+		})
+	}
+}
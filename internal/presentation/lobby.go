@@ -0,0 +1,111 @@
+package presentation
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// Lobby shows a new verse from a generator at a fixed interval,
+// e.g. on the lobby screen before and after the service
+type Lobby struct {
+	mu      sync.Mutex
+	vp      *VersePresentation
+	stop    chan struct{} // Closed to stop the lobby, nil when stopped
+	onError func(error)
+}
+
+// NewLobby creates a stopped lobby mode for a presentation
+func NewLobby(vp *VersePresentation) *Lobby {
+	return &Lobby{
+		vp: vp,
+	}
+}
+
+// SetOnError sets a callback for errors picking a verse
+func (l *Lobby) SetOnError(onError func(error)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onError = onError
+}
+
+// Start shows a verse right away and then a new one every interval,
+// replacing any running lobby
+func (l *Lobby) Start(generator bible.Generator, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("lobby interval must be positive")
+	}
+
+	// Check the generator works before taking over the screen
+	verse, err := generator.Next()
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.stop != nil {
+		close(l.stop)
+	}
+	stop := make(chan struct{})
+	l.stop = stop
+	l.mu.Unlock()
+
+	l.vp.SetVerse(verse)
+	go l.run(generator, interval, stop)
+	return nil
+}
+
+// Stop stops the lobby, leaving the last verse on screen
+func (l *Lobby) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		close(l.stop)
+		l.stop = nil
+	}
+}
+
+// Running returns whether the lobby is running
+func (l *Lobby) Running() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stop != nil
+}
+
+// run shows a new verse every interval until stop is closed
+func (l *Lobby) run(generator bible.Generator, interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		verse, err := generator.Next()
+		if err != nil {
+			slog.Warn("Lobby failed to pick a verse", logging.Action("lobby"), "error", err)
+			l.mu.Lock()
+			onError := l.onError
+			l.mu.Unlock()
+			if onError != nil {
+				onError(err)
+			}
+			continue
+		}
+
+		// Don't show a verse after being stopped meanwhile
+		select {
+		case <-stop:
+			return
+		default:
+			l.vp.SetVerse(verse)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message styles
+const (
+	MessageTicker     = "ticker"      // Scrolls along the bottom of the screen
+	MessageLowerThird = "lower-third" // Band across the bottom third of the screen
+)
+
+// DefaultMessageDuration is how long a message stays up by default
+const DefaultMessageDuration = 15 * time.Second
+
+// Message is a short notice shown over the current slide,
+// e.g. "Parent of child #12 please come to the nursery"
+type Message struct {
+	ID       int
+	Text     string
+	Style    string
+	Duration time.Duration // 0 shows the message until it is dismissed
+}
+
+// MessageQueue shows messages one at a time in the order they were added
+type MessageQueue struct {
+	mu       sync.Mutex
+	nextID   int
+	current  *Message
+	pending  []*Message
+	timer    *time.Timer
+	onChange func()
+}
+
+// NewMessageQueue creates an empty message queue
+func NewMessageQueue() *MessageQueue {
+	return &MessageQueue{}
+}
+
+// SetOnChange sets a callback for when the current or pending messages change
+func (q *MessageQueue) SetOnChange(onChange func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onChange = onChange
+}
+
+// Add queues a message, showing it right away if nothing else is shown
+func (q *MessageQueue) Add(text, style string, duration time.Duration) (*Message, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("message text is empty")
+	}
+	if style != MessageTicker && style != MessageLowerThird {
+		return nil, fmt.Errorf("unknown message style: %q", style)
+	}
+	if duration < 0 {
+		return nil, fmt.Errorf("message duration must not be negative")
+	}
+
+	q.mu.Lock()
+	q.nextID++
+	msg := &Message{
+		ID:       q.nextID,
+		Text:     text,
+		Style:    style,
+		Duration: duration,
+	}
+	q.pending = append(q.pending, msg)
+	if q.current == nil {
+		q.showNext()
+	}
+	q.mu.Unlock()
+
+	q.notify()
+	return msg, nil
+}
+
+// Dismiss ends the current message and shows the next one
+func (q *MessageQueue) Dismiss() {
+	q.mu.Lock()
+	if q.current == nil {
+		q.mu.Unlock()
+		return
+	}
+	q.showNext()
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// Remove removes a message, dismissing it if it is being shown
+func (q *MessageQueue) Remove(id int) {
+	q.mu.Lock()
+	if q.current != nil && q.current.ID == id {
+		q.showNext()
+	} else {
+		for i, msg := range q.pending {
+			if msg.ID == id {
+				q.pending = append(q.pending[:i], q.pending[i+1:]...)
+				break
+			}
+		}
+	}
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// Clear dismisses the current message and empties the queue
+func (q *MessageQueue) Clear() {
+	q.mu.Lock()
+	q.pending = nil
+	q.showNext()
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// Current returns the message being shown, or nil
+func (q *MessageQueue) Current() *Message {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.current
+}
+
+// Pending returns the messages waiting to be shown, next first
+func (q *MessageQueue) Pending() []*Message {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*Message(nil), q.pending...)
+}
+
+// showNext replaces the current message with the next pending one;
+// the caller must hold the lock
+func (q *MessageQueue) showNext() {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+
+	q.current = nil
+	if len(q.pending) == 0 {
+		return
+	}
+
+	q.current = q.pending[0]
+	q.pending = q.pending[1:]
+	if q.current.Duration > 0 {
+		id := q.current.ID
+		q.timer = time.AfterFunc(q.current.Duration, func() {
+			q.expire(id)
+		})
+	}
+}
+
+// expire ends a timed message unless it was already dismissed
+func (q *MessageQueue) expire(id int) {
+	q.mu.Lock()
+	if q.current == nil || q.current.ID != id {
+		q.mu.Unlock()
+		return
+	}
+	q.showNext()
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// notify calls the change callback
+func (q *MessageQueue) notify() {
+	q.mu.Lock()
+	onChange := q.onChange
+	q.mu.Unlock()
+
+	if onChange != nil {
+		onChange()
+	}
+}
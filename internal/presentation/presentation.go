@@ -2,47 +2,98 @@ package presentation
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
 )
 
-// VersePresentation represents the current verse being presented
+// VersePresentation represents the current slide being presented,
+// which is usually a Bible verse
 type VersePresentation struct {
-	CurrentVerse *bible.Verse
+	CurrentSlide *Slide
+	History      *History
 	mu           sync.RWMutex
-	observers    []func(*bible.Verse)
+	observers    []func(*Slide)
 }
 
 // NewVersePresentation creates a new verse presentation
 func NewVersePresentation() *VersePresentation {
 	return &VersePresentation{
-		observers: make([]func(*bible.Verse), 0),
+		History:   NewHistory(DefaultHistoryLimit),
+		observers: make([]func(*Slide), 0),
 	}
 }
 
-// SetVerse sets the current verse and notifies all observers
-func (vp *VersePresentation) SetVerse(verse *bible.Verse) {
+// SetSlide sets the current slide, records it in the history and notifies all observers
+func (vp *VersePresentation) SetSlide(slide *Slide) {
+	if slide.IsVerse() {
+		verse := slide.Verse
+		slog.Debug("Showing verse", logging.Translation(verse.Translation), logging.Reference(verse.Book, verse.Chapter, verse.Verse))
+	} else if slide != nil {
+		slog.Debug("Showing slide", "title", slide.Title)
+	}
+	vp.History.Record(slide)
+	vp.showSlide(slide)
+}
+
+// showSlide sets the current slide and notifies all observers
+func (vp *VersePresentation) showSlide(slide *Slide) {
 	vp.mu.Lock()
-	vp.CurrentVerse = verse
+	vp.CurrentSlide = slide
 	observers := vp.observers // Copy to avoid holding lock during callbacks
 	vp.mu.Unlock()
 
 	// Notify all observers
 	for _, observer := range observers {
-		observer(verse)
+		observer(slide)
 	}
 }
 
-// GetVerse returns the current verse
-func (vp *VersePresentation) GetVerse() *bible.Verse {
+// GetSlide returns the current slide
+func (vp *VersePresentation) GetSlide() *Slide {
 	vp.mu.RLock()
 	defer vp.mu.RUnlock()
-	return vp.CurrentVerse
+	return vp.CurrentSlide
+}
+
+// SetVerse sets the current verse and notifies all observers
+func (vp *VersePresentation) SetVerse(verse *bible.Verse) {
+	vp.SetSlide(NewVerseSlide(verse))
 }
 
-// AddObserver adds a function to be called when the verse changes
-func (vp *VersePresentation) AddObserver(observer func(*bible.Verse)) {
+// GetVerse returns the current verse, or nil if the current slide is not a verse
+func (vp *VersePresentation) GetVerse() *bible.Verse {
+	slide := vp.GetSlide()
+	if !slide.IsVerse() {
+		return nil
+	}
+	return slide.Verse
+}
+
+// Back shows the previously shown slide from the history
+func (vp *VersePresentation) Back() error {
+	slide, ok := vp.History.Back()
+	if !ok {
+		return fmt.Errorf("no earlier slide in history")
+	}
+	vp.showSlide(slide)
+	return nil
+}
+
+// Forward shows the next slide from the history after going back
+func (vp *VersePresentation) Forward() error {
+	slide, ok := vp.History.Forward()
+	if !ok {
+		return fmt.Errorf("no later slide in history")
+	}
+	vp.showSlide(slide)
+	return nil
+}
+
+// AddObserver adds a function to be called when the slide changes
+func (vp *VersePresentation) AddObserver(observer func(*Slide)) {
 	vp.mu.Lock()
 	defer vp.mu.Unlock()
 	vp.observers = append(vp.observers, observer)
@@ -106,3 +157,42 @@ func (vp *VersePresentation) SwitchTranslation(newTranslation string) error {
 	vp.SetVerse(v)
 	return nil
 }
+
+// FormatReference formats the reference of a verse for display in the
+// translation's language, preferring the localized chapter header if available
+func FormatReference(verse *bible.Verse) string {
+	return fmt.Sprintf("%s:%d %s", chapterName(verse), verse.Verse, verse.Translation)
+}
+
+// FormatPassage formats the reference of verses in one chapter, e.g. "John 3:16-18 NLT"
+func FormatPassage(verses []*bible.Verse) string {
+	first, last := verses[0], verses[len(verses)-1]
+	if first.Verse == last.Verse {
+		return FormatReference(first)
+	}
+	return fmt.Sprintf("%s:%d-%d %s", chapterName(first), first.Verse, last.Verse, first.Translation)
+}
+
+// chapterName returns the localized chapter header of a verse, e.g. "JOHN 3",
+// or the localized book name and chapter number
+func chapterName(verse *bible.Verse) string {
+	if header, ok, err := bible.GetChapterHeader(verse.Translation, verse.Book, verse.Chapter); err == nil && ok && header != "" {
+		return header
+	}
+
+	book, err := bible.GetLocalizedBookName(verse.Translation, verse.Book)
+	if err != nil {
+		slog.Warn("Failed to localize book name", logging.Translation(verse.Translation), "book", verse.Book, "error", err)
+	}
+	return fmt.Sprintf("%s %d", book, verse.Chapter)
+}
+
+// SectionHeading returns the heading of the section a verse is in, or "" if there is none
+func SectionHeading(verse *bible.Verse) string {
+	heading, _, err := bible.GetSectionHeading(verse.Translation, verse.Book, verse.Chapter, verse.Verse)
+	if err != nil {
+		slog.Warn("Failed to get the section heading", logging.Translation(verse.Translation),
+			logging.Reference(verse.Book, verse.Chapter, verse.Verse), "error", err)
+	}
+	return heading
+}
@@ -0,0 +1,66 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+)
+
+// Slide represents anything that can be shown on the live window,
+// such as a Bible verse, a sermon point or an announcement
+type Slide struct {
+	ID     int          `json:"id,omitempty"`
+	Title  string       `json:"title"`
+	Body   string       `json:"body"`
+	Footer string       `json:"footer"`
+	Theme  string       `json:"theme"`
+	Verse  *bible.Verse `json:"verse,omitempty"` // Set when the slide shows a Bible verse
+
+	// Heading of the section the verse is in, e.g. "The Creation"
+	Section string `json:"section,omitempty"`
+
+	// Media library background shown instead of the theme's background
+	Background     string  `json:"background,omitempty"`
+	BackgroundMode string  `json:"background_mode,omitempty"`
+	BackgroundDim  float64 `json:"background_dim,omitempty"`
+}
+
+// NewVerseSlide creates a slide showing a Bible verse
+func NewVerseSlide(verse *bible.Verse) *Slide {
+	if verse == nil {
+		return nil
+	}
+	return &Slide{
+		Title:   FormatReference(verse),
+		Body:    verse.Text,
+		Verse:   verse,
+		Section: SectionHeading(verse),
+	}
+}
+
+// NewPassageSlide creates a slide showing verses of one chapter,
+// numbering the verses when there is more than one
+func NewPassageSlide(verses []*bible.Verse) *Slide {
+	if len(verses) == 0 {
+		return nil
+	}
+	if len(verses) == 1 {
+		return NewVerseSlide(verses[0])
+	}
+
+	texts := make([]string, len(verses))
+	for i, verse := range verses {
+		texts[i] = fmt.Sprintf("%d %s", verse.Verse, verse.Text)
+	}
+	return &Slide{
+		Title:   FormatPassage(verses),
+		Body:    strings.Join(texts, " "),
+		Section: SectionHeading(verses[0]),
+	}
+}
+
+// IsVerse returns whether the slide shows a Bible verse
+func (s *Slide) IsVerse() bool {
+	return s != nil && s.Verse != nil
+}
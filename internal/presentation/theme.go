@@ -0,0 +1,165 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultTheme is the slide theme used when a slide doesn't specify one
+const DefaultTheme = "Default"
+
+// Theme is a named look for slides
+type Theme struct {
+	Background    color.Color
+	Foreground    color.Color
+	WordsOfChrist color.Color
+}
+
+// themesMutex guards themes, which can change while slides are drawn
+var themesMutex sync.RWMutex
+
+// themes are the slide themes available to slides
+var themes = map[string]Theme{
+	DefaultTheme: {
+		Background:    color.Black,
+		Foreground:    color.White,
+		WordsOfChrist: color.NRGBA{R: 0xff, G: 0x52, B: 0x52, A: 0xff},
+	},
+	"Light": {
+		Background:    color.White,
+		Foreground:    color.NRGBA{R: 0x21, G: 0x21, B: 0x21, A: 0xff},
+		WordsOfChrist: color.NRGBA{R: 0xb7, G: 0x1c, B: 0x1c, A: 0xff},
+	},
+	"Announcement": {
+		Background:    color.NRGBA{R: 0x0d, G: 0x24, B: 0x4d, A: 0xff}, // Navy
+		Foreground:    color.White,
+		WordsOfChrist: color.NRGBA{R: 0xff, G: 0x8a, B: 0x80, A: 0xff},
+	},
+}
+
+// ThemeNames returns the names of the slide themes, default first
+func ThemeNames() []string {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		if name != DefaultTheme {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultTheme}, names...)
+}
+
+// GetTheme returns the slide theme with the given name, or the default theme
+func GetTheme(name string) Theme {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	return themes[DefaultTheme]
+}
+
+// AddTheme adds a slide theme, or replaces the one with the same name
+func AddTheme(name string, theme Theme) {
+	themesMutex.Lock()
+	defer themesMutex.Unlock()
+
+	themes[name] = theme
+}
+
+// ThemeSpec is a slide theme as written in a theme file or the configuration
+// file, with colors such as "#0d244d"
+type ThemeSpec struct {
+	Background    string `json:"background" toml:"background"`
+	Foreground    string `json:"foreground" toml:"foreground"`
+	WordsOfChrist string `json:"words_of_christ" toml:"words_of_christ"`
+}
+
+// Theme returns the slide theme of the spec.
+// Colors the spec leaves out come from the default theme.
+func (s ThemeSpec) Theme() (Theme, error) {
+	theme := GetTheme(DefaultTheme)
+	for _, field := range []struct {
+		value string
+		color *color.Color
+	}{
+		{s.Background, &theme.Background},
+		{s.Foreground, &theme.Foreground},
+		{s.WordsOfChrist, &theme.WordsOfChrist},
+	} {
+		if field.value == "" {
+			continue
+		}
+		c, err := parseHexColor(field.value)
+		if err != nil {
+			return theme, err
+		}
+		*field.color = c
+	}
+	return theme, nil
+}
+
+// LoadThemes adds the slide themes in the JSON files of dir, named after their files,
+// and returns their names. Colors a file leaves out come from the default theme.
+// A missing folder has no themes.
+func LoadThemes(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var errs []error
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		theme, err := loadThemeFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(file), err))
+			continue
+		}
+		AddTheme(name, theme)
+		names = append(names, name)
+	}
+	return names, errors.Join(errs...)
+}
+
+// loadThemeFile reads a slide theme from a JSON file
+func loadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	var spec ThemeSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return Theme{}, err
+	}
+	return spec.Theme()
+}
+
+// parseHexColor parses a color written as "#rrggbb" or "#rgb"
+func parseHexColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	var r, g, b uint8
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("invalid color: %s", s)
+	}
+	if _, err := fmt.Sscanf(hex, "%02x%02x%02x", &r, &g, &b); err != nil {
+		return nil, fmt.Errorf("invalid color: %s", s)
+	}
+	return color.NRGBA{R: r, G: g, B: b, A: 0xff}, nil
+}
@@ -0,0 +1,230 @@
+package presentation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Timer modes
+const (
+	TimerModeCountdown   = "countdown"    // Counts down a duration
+	TimerModeCountdownTo = "countdown-to" // Counts down to a time of day
+	TimerModeStopwatch   = "stopwatch"    // Counts up from zero
+	TimerModeClock       = "clock"        // Shows the wall clock
+)
+
+// Where the timer is shown on the live window. Stage displays show every timer.
+const (
+	TimerDisplayFullScreen = "fullscreen"
+	TimerDisplayCorner     = "corner"
+	TimerDisplayStage      = "stage" // Only on the stage displays, e.g. the preacher's countdown
+)
+
+// What happens when a countdown reaches zero
+const (
+	TimerExpireNone  = "none"  // Keep showing 00:00
+	TimerExpireHide  = "hide"  // Hide the timer
+	TimerExpireSlide = "slide" // Hide the timer and show a library slide, e.g. the church logo
+)
+
+// TimerSettings configures a timer
+type TimerSettings struct {
+	Mode          string
+	Duration      time.Duration // Length of a countdown
+	Target        time.Time     // End of a countdown to a time of day
+	Label         string        // Text shown with the timer, e.g. "Service starts in"
+	Display       string
+	OnExpiry      string
+	ExpirySlideID int // Library slide shown by TimerExpireSlide
+}
+
+// TimerStatus is a snapshot of the timer
+type TimerStatus struct {
+	Running  bool
+	Expired  bool
+	Text     string // Formatted time, e.g. "05:00" or "10:30"
+	Settings TimerSettings
+}
+
+// LabeledText returns the time after the label, e.g. "Service starts in 05:00",
+// or an empty string when the timer isn't running
+func (s TimerStatus) LabeledText() string {
+	if !s.Running || s.Settings.Label == "" {
+		return s.Text
+	}
+	return s.Settings.Label + " " + s.Text
+}
+
+// Timer is a countdown, stopwatch or clock shown on the live window and the stage displays
+type Timer struct {
+	mu       sync.Mutex
+	settings TimerSettings
+	running  bool
+	started  time.Time
+	end      time.Time // End of a countdown, zero for the stopwatch and clock
+	expired  bool
+	expiry   *time.Timer
+	onExpire func(TimerSettings)
+}
+
+// NewTimer creates a new stopped timer
+func NewTimer() *Timer {
+	return &Timer{}
+}
+
+// SetOnExpire sets the callback run when a countdown reaches zero
+func (t *Timer) SetOnExpire(onExpire func(TimerSettings)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onExpire = onExpire
+}
+
+// Start starts the timer, replacing any running timer
+func (t *Timer) Start(settings TimerSettings) error {
+	now := time.Now()
+
+	var end time.Time
+	switch settings.Mode {
+	case TimerModeCountdown:
+		if settings.Duration <= 0 {
+			return fmt.Errorf("countdown duration must be positive")
+		}
+		end = now.Add(settings.Duration)
+	case TimerModeCountdownTo:
+		if !settings.Target.After(now) {
+			return fmt.Errorf("countdown target %s has already passed", settings.Target.Format("15:04"))
+		}
+		end = settings.Target
+	case TimerModeStopwatch, TimerModeClock:
+	default:
+		return fmt.Errorf("unknown timer mode: %q", settings.Mode)
+	}
+
+	switch settings.Display {
+	case "":
+		settings.Display = TimerDisplayFullScreen
+	case TimerDisplayFullScreen, TimerDisplayCorner, TimerDisplayStage:
+	default:
+		return fmt.Errorf("unknown timer display: %q", settings.Display)
+	}
+
+	switch settings.OnExpiry {
+	case "":
+		settings.OnExpiry = TimerExpireNone
+	case TimerExpireNone, TimerExpireHide:
+	case TimerExpireSlide:
+		if settings.ExpirySlideID == 0 {
+			return fmt.Errorf("a slide is required to show on expiry")
+		}
+	default:
+		return fmt.Errorf("unknown timer expiry action: %q", settings.OnExpiry)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopExpiry()
+	t.settings = settings
+	t.running = true
+	t.expired = false
+	t.started = now
+	t.end = end
+	if !end.IsZero() {
+		t.expiry = time.AfterFunc(end.Sub(now), t.expire)
+	}
+	return nil
+}
+
+// Stop stops and hides the timer
+func (t *Timer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopExpiry()
+	t.running = false
+	t.expired = false
+}
+
+// Status returns the current timer state
+func (t *Timer) Status() TimerStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := TimerStatus{
+		Running:  t.running,
+		Expired:  t.expired,
+		Settings: t.settings,
+	}
+	if !t.running {
+		return status
+	}
+
+	now := time.Now()
+	switch t.settings.Mode {
+	case TimerModeCountdown, TimerModeCountdownTo:
+		status.Text = FormatDuration(t.end.Sub(now))
+	case TimerModeStopwatch:
+		status.Text = FormatDuration(now.Sub(t.started))
+	case TimerModeClock:
+		status.Text = now.Format("15:04")
+	}
+	return status
+}
+
+// expire runs the expiry action when a countdown reaches zero
+func (t *Timer) expire() {
+	t.mu.Lock()
+	if !t.running || time.Now().Before(t.end) {
+		t.mu.Unlock()
+		return // Stopped or restarted meanwhile
+	}
+	t.expired = true
+	if t.settings.OnExpiry != TimerExpireNone {
+		t.running = false
+	}
+	settings := t.settings
+	onExpire := t.onExpire
+	t.mu.Unlock()
+
+	if onExpire != nil {
+		onExpire(settings)
+	}
+}
+
+// stopExpiry cancels a pending expiry; the caller must hold the lock
+func (t *Timer) stopExpiry() {
+	if t.expiry != nil {
+		t.expiry.Stop()
+		t.expiry = nil
+	}
+}
+
+// ParseTimeOfDay parses a time of day such as "10:30" as its next occurrence after now
+func ParseTimeOfDay(s string, now time.Time) (time.Time, error) {
+	clock, err := time.Parse("15:04", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+
+	target := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !target.After(now) {
+		target = target.AddDate(0, 0, 1)
+	}
+	return target, nil
+}
+
+// FormatDuration formats a duration as MM:SS, or H:MM:SS for an hour or more
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+
+	hours := int(d / time.Hour)
+	minutes := int(d%time.Hour) / int(time.Minute)
+	seconds := int(d%time.Minute) / int(time.Second)
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
@@ -0,0 +1,22 @@
+// Package schema updates the tables of the database created by earlier versions
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// AddColumn adds a column to an existing table unless it already exists
+func AddColumn(db *sql.DB, table, name, definition string) error {
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+		table, name,
+	).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, name, definition))
+	return err
+}
@@ -0,0 +1,305 @@
+// Package server exposes the presentation state over HTTP,
+// e.g. the stage display as a web page for tablets and extra monitors
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+//go:embed stage.html
+var stagePage []byte
+
+// Server serves the web stage display and its JSON state
+type Server struct {
+	httpServer        *http.Server
+	token             string
+	versePresentation *presentation.VersePresentation
+	autoAdvance       *presentation.AutoAdvance
+	timer             *presentation.Timer
+}
+
+// StageVerse is a verse as shown on the stage display
+type StageVerse struct {
+	Reference string `json:"reference"`
+	Text      string `json:"text"`
+}
+
+// StageState is the JSON state polled by the web stage display
+type StageState struct {
+	Current   *StageVerse `json:"current,omitempty"`
+	Next      *StageVerse `json:"next,omitempty"`
+	Clock     string      `json:"clock"`
+	Countdown string      `json:"countdown,omitempty"` // The running timer, as on the stage window
+}
+
+// FollowState is the JSON state of follow-along mode
+type FollowState struct {
+	State     string `json:"state"`
+	Remaining string `json:"remaining,omitempty"`
+	End       string `json:"end,omitempty"`
+}
+
+// TimerRequest is the JSON body for starting a timer
+type TimerRequest struct {
+	Mode          string  `json:"mode"`
+	Minutes       float64 `json:"minutes,omitempty"` // Length of a countdown
+	At            string  `json:"at,omitempty"`      // Time of day of a countdown-to, e.g. "10:30"
+	Label         string  `json:"label,omitempty"`
+	Display       string  `json:"display,omitempty"`
+	OnExpiry      string  `json:"on_expiry,omitempty"`
+	ExpirySlideID int     `json:"expiry_slide_id,omitempty"`
+}
+
+// TimerState is the JSON state of the live window timer
+type TimerState struct {
+	Running bool   `json:"running"`
+	Expired bool   `json:"expired"`
+	Mode    string `json:"mode,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Label   string `json:"label,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// NewServer creates a new HTTP server for the given presentation state.
+// Requests that control the presentation must carry the token as a bearer
+// token; without a token they are refused.
+func NewServer(
+	addr string,
+	token string,
+	versePresentation *presentation.VersePresentation,
+	autoAdvance *presentation.AutoAdvance,
+	timer *presentation.Timer,
+) *Server {
+	s := &Server{
+		token:             token,
+		versePresentation: versePresentation,
+		autoAdvance:       autoAdvance,
+		timer:             timer,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stage", s.handleStagePage)
+	mux.HandleFunc("GET /api/stage", s.handleStageState)
+	mux.HandleFunc("GET /api/follow", s.handleFollowState)
+	mux.HandleFunc("POST /api/follow/pause", s.control(s.handleFollowPause))
+	mux.HandleFunc("POST /api/follow/resume", s.control(s.handleFollowResume))
+	mux.HandleFunc("POST /api/follow/stop", s.control(s.handleFollowStop))
+	mux.HandleFunc("GET /api/timer", s.handleTimerState)
+	mux.HandleFunc("POST /api/timer/start", s.control(s.handleTimerStart))
+	mux.HandleFunc("POST /api/timer/stop", s.control(s.handleTimerStop))
+
+	s.httpServer = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Start listens on the server's address and serves requests in the background.
+// It returns the error when the address can't be used, e.g. when it is in use.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.httpServer.Addr, err)
+	}
+
+	slog.Info("HTTP server listening", "addr", listener.Addr().String())
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			slog.Error("HTTP server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop shuts the server down
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		slog.Warn("Error shutting down HTTP server", "error", err)
+	}
+}
+
+// control wraps a handler that changes the presentation, so it only runs for
+// requests with the shared token, and not for requests sent by web pages of
+// other sites
+func (s *Server) control(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !sameHost(origin, r.Host) {
+			http.Error(w, "requests from other sites are not allowed", http.StatusForbidden)
+			return
+		}
+		if s.token == "" {
+			http.Error(w, "remote control is off; set token in the [network] section of the configuration file", http.StatusForbidden)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="Mr Verse"`)
+			http.Error(w, "missing or wrong token", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// sameHost reports whether an Origin header names the host a request was sent to
+func sameHost(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// handleStagePage serves the web stage display
+func (s *Server) handleStagePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(stagePage)
+}
+
+// handleStageState serves the current stage state as JSON
+func (s *Server) handleStageState(w http.ResponseWriter, r *http.Request) {
+	state := StageState{
+		Clock: time.Now().Format("15:04:05"),
+	}
+
+	if slide := s.versePresentation.GetSlide(); slide != nil {
+		state.Current = &StageVerse{
+			Reference: slide.Title,
+			Text:      slide.Body,
+		}
+
+		if verse := slide.Verse; verse != nil {
+			next, err := bible.GetNextVerse(verse.Translation, verse.Book, verse.Chapter, verse.Verse)
+			if err == nil {
+				state.Next = &StageVerse{
+					Reference: presentation.FormatReference(next),
+					Text:      next.Text,
+				}
+			}
+		}
+	}
+
+	state.Countdown = s.timer.Status().LabeledText()
+
+	writeJSON(w, state)
+}
+
+// handleFollowState serves the follow-along state as JSON
+func (s *Server) handleFollowState(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.followState())
+}
+
+// handleFollowPause pauses follow-along mode
+func (s *Server) handleFollowPause(w http.ResponseWriter, r *http.Request) {
+	s.autoAdvance.Pause()
+	writeJSON(w, s.followState())
+}
+
+// handleFollowResume resumes follow-along mode after a pause
+func (s *Server) handleFollowResume(w http.ResponseWriter, r *http.Request) {
+	s.autoAdvance.Resume()
+	writeJSON(w, s.followState())
+}
+
+// handleFollowStop stops follow-along mode
+func (s *Server) handleFollowStop(w http.ResponseWriter, r *http.Request) {
+	s.autoAdvance.Stop()
+	writeJSON(w, s.followState())
+}
+
+// followState returns the current follow-along state
+func (s *Server) followState() FollowState {
+	status := s.autoAdvance.Status()
+	state := FollowState{
+		State: status.State,
+	}
+	if status.State != presentation.AutoAdvanceStopped {
+		state.Remaining = presentation.FormatDuration(status.Remaining)
+		if end := status.Settings; end.EndBook != "" {
+			state.End = fmt.Sprintf("%s %d:%d", end.EndBook, end.EndChapter, end.EndVerse)
+		}
+	}
+	return state
+}
+
+// handleTimerState serves the timer state as JSON
+func (s *Server) handleTimerState(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.timerState())
+}
+
+// handleTimerStart starts a timer from a JSON TimerRequest
+func (s *Server) handleTimerStart(w http.ResponseWriter, r *http.Request) {
+	var req TimerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid timer request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	settings := presentation.TimerSettings{
+		Mode:          req.Mode,
+		Duration:      time.Duration(req.Minutes * float64(time.Minute)),
+		Label:         req.Label,
+		Display:       req.Display,
+		OnExpiry:      req.OnExpiry,
+		ExpirySlideID: req.ExpirySlideID,
+	}
+	if req.Mode == presentation.TimerModeCountdownTo {
+		target, err := presentation.ParseTimeOfDay(req.At, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		settings.Target = target
+	}
+
+	if err := s.timer.Start(settings); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, s.timerState())
+}
+
+// handleTimerStop stops the timer
+func (s *Server) handleTimerStop(w http.ResponseWriter, r *http.Request) {
+	s.timer.Stop()
+	writeJSON(w, s.timerState())
+}
+
+// timerState returns the current timer state
+func (s *Server) timerState() TimerState {
+	status := s.timer.Status()
+	state := TimerState{
+		Running: status.Running,
+		Expired: status.Expired,
+	}
+	if status.Running {
+		state.Mode = status.Settings.Mode
+		state.Text = status.Text
+		state.Label = status.Settings.Label
+		state.Display = status.Settings.Display
+	}
+	return state
+}
+
+// writeJSON writes a value as a JSON response
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Error writing JSON response", "error", err)
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestControl(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		origin        string
+		want          int
+	}{
+		{name: "token", token: "secret", authorization: "Bearer secret", want: http.StatusOK},
+		{name: "no token configured", authorization: "Bearer ", want: http.StatusForbidden},
+		{name: "missing token", token: "secret", want: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", authorization: "Bearer guess", want: http.StatusUnauthorized},
+		{name: "basic authorization", token: "secret", authorization: "Basic secret", want: http.StatusUnauthorized},
+		{name: "same origin", token: "secret", authorization: "Bearer secret", origin: "http://stage.local:8080", want: http.StatusOK},
+		{name: "other site", token: "secret", authorization: "Bearer secret", origin: "http://example.com", want: http.StatusForbidden},
+		{name: "opaque origin", token: "secret", authorization: "Bearer secret", origin: "null", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{token: tt.token}
+			ran := false
+			handler := s.control(func(w http.ResponseWriter, r *http.Request) {
+				ran = true
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "http://stage.local:8080/api/follow/stop", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			if ran != (tt.want == http.StatusOK) {
+				t.Errorf("handler ran = %t, want %t", ran, !ran)
+			}
+		})
+	}
+}
+
+func TestStartAddressInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	s := NewServer(listener.Addr().String(), "", nil, nil, nil)
+	if err := s.Start(); err == nil {
+		s.Stop()
+		t.Errorf("Start() on %s succeeded, want an error for the address in use", listener.Addr())
+	}
+}
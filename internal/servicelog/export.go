@@ -0,0 +1,134 @@
+package servicelog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// Export formats
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatCSV, FormatMarkdown, FormatJSON}
+
+// Export writes the entries in the given format
+func Export(w io.Writer, format string, entries []*Entry) error {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return ExportCSV(w, entries)
+	case FormatMarkdown, "markdown":
+		return ExportMarkdown(w, entries)
+	case FormatJSON:
+		return ExportJSON(w, entries)
+	}
+	return fmt.Errorf("unsupported export format: %s", format)
+}
+
+// ExportCSV writes the entries as CSV with a header row
+func ExportCSV(w io.Writer, entries []*Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"shown_at", "duration_seconds", "kind", "reference", "translation"}); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		err := cw.Write([]string{
+			e.ShownAt.Format(time.RFC3339),
+			strconv.Itoa(e.DurationSeconds),
+			e.Kind,
+			e.Reference,
+			e.Translation,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportMarkdown writes the entries as a Markdown document with a table per day
+func ExportMarkdown(w io.Writer, entries []*Entry) error {
+	var b strings.Builder
+	b.WriteString("# Service Log\n")
+
+	day := ""
+	for _, e := range entries {
+		if d := e.ShownAt.Format("Monday, January 2, 2006"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n\n", day)
+			b.WriteString("| Time | Reference | Translation | On Screen |\n")
+			b.WriteString("| --- | --- | --- | --- |\n")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			e.ShownAt.Format("15:04:05"),
+			markdownEscape(e.Reference),
+			markdownEscape(e.Translation),
+			presentation.FormatDuration(time.Duration(e.DurationSeconds)*time.Second),
+		)
+	}
+
+	if len(entries) == 0 {
+		b.WriteString("\nNothing was shown live in this period.\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ExportJSON writes the entries as an indented JSON array
+func ExportJSON(w io.Writer, entries []*Entry) error {
+	if entries == nil {
+		entries = []*Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// markdownEscape keeps text from breaking a Markdown table row
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// ParseDate parses a YYYY-MM-DD date in local time. An empty string gives the zero time.
+func ParseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+// DateRange returns the time range covering the from and to dates, both inclusive.
+// Either date may be empty to leave that end open.
+func DateRange(fromDate, toDate string) (from, to time.Time, err error) {
+	from, err = ParseDate(fromDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err = ParseDate(toDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1) // Include the whole last day
+	}
+	return from, to, nil
+}
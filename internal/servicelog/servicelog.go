@@ -0,0 +1,175 @@
+// Package servicelog records everything taken live during a service
+// and exports it for reporting and sermon archives
+package servicelog
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// DB is the database connection used by the service log
+var DB *sql.DB
+
+// Kinds of logged items
+const (
+	KindVerse = "verse"
+	KindSlide = "slide"
+)
+
+// Entry is an item that was shown live
+type Entry struct {
+	ID              int       `json:"-"`
+	ShownAt         time.Time `json:"shown_at"`
+	DurationSeconds int       `json:"duration_seconds"`
+	Kind            string    `json:"kind"`
+	Reference       string    `json:"reference"`
+	Translation     string    `json:"translation,omitempty"`
+}
+
+// Init sets the database connection and creates the service_log table if it doesn't exist
+func Init(db *sql.DB) error {
+	DB = db
+
+	_, err := DB.Exec(`
+		CREATE TABLE IF NOT EXISTS service_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			shown_at DATETIME NOT NULL,
+			duration_seconds INTEGER NOT NULL DEFAULT 0,
+			kind TEXT NOT NULL,
+			reference TEXT NOT NULL,
+			translation TEXT NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = DB.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_service_log_shown_at
+		ON service_log(shown_at)
+	`)
+	return err
+}
+
+// GetEntries returns the entries shown between from and to, oldest first.
+// A zero from or to leaves that end of the range open.
+func GetEntries(from, to time.Time) ([]*Entry, error) {
+	query := `
+		SELECT id, shown_at, duration_seconds, kind, reference, translation
+		FROM service_log
+		WHERE 1 = 1
+	`
+	var args []any
+	if !from.IsZero() {
+		query += " AND shown_at >= ?"
+		args = append(args, from.UTC())
+	}
+	if !to.IsZero() {
+		query += " AND shown_at < ?"
+		args = append(args, to.UTC())
+	}
+	query += " ORDER BY shown_at, id"
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.ShownAt, &e.DurationSeconds, &e.Kind, &e.Reference, &e.Translation); err != nil {
+			return nil, err
+		}
+		e.ShownAt = e.ShownAt.Local()
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// Recorder logs the slides taken live. The entry for a slide is written
+// when it is shown and its duration is filled in when it is replaced or stopped.
+type Recorder struct {
+	mu        sync.Mutex
+	currentID int64
+	shownAt   time.Time
+}
+
+// NewRecorder creates a new service log recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Show logs a slide being taken live, ending the previous one
+func (r *Recorder) Show(slide *presentation.Slide) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.finish(); err != nil {
+		return err
+	}
+	if slide == nil {
+		return nil
+	}
+
+	entry := Entry{
+		ShownAt:   time.Now(),
+		Kind:      KindSlide,
+		Reference: slide.Title,
+	}
+	if slide.IsVerse() {
+		entry.Kind = KindVerse
+		entry.Reference = Reference(slide)
+		entry.Translation = slide.Verse.Translation
+	}
+
+	result, err := DB.Exec(`
+		INSERT INTO service_log (shown_at, kind, reference, translation)
+		VALUES (?, ?, ?, ?)
+	`, entry.ShownAt.UTC(), entry.Kind, entry.Reference, entry.Translation)
+	if err != nil {
+		return err
+	}
+
+	r.currentID, err = result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.shownAt = entry.ShownAt
+	return nil
+}
+
+// Stop ends the item currently shown, e.g. when the live window closes
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.finish()
+}
+
+// finish records how long the current item was on screen
+func (r *Recorder) finish() error {
+	if r.currentID == 0 {
+		return nil
+	}
+
+	duration := int(time.Since(r.shownAt).Round(time.Second).Seconds())
+	_, err := DB.Exec(
+		"UPDATE service_log SET duration_seconds = ? WHERE id = ?",
+		duration,
+		r.currentID,
+	)
+	r.currentID = 0
+	return err
+}
+
+// Reference returns the logged reference for a verse slide, e.g. "John 3:16"
+func Reference(slide *presentation.Slide) string {
+	v := slide.Verse
+	return fmt.Sprintf("%s %d:%d", v.Book, v.Chapter, v.Verse)
+}
@@ -0,0 +1,200 @@
+package songs
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// DB is the database connection used by the song library
+var DB *sql.DB
+
+// Init sets the database connection and creates the song tables if they don't exist
+func Init(db *sql.DB) error {
+	DB = db
+
+	_, err := DB.Exec(`
+		CREATE TABLE IF NOT EXISTS songs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			author TEXT NOT NULL DEFAULT '',
+			ccli TEXT NOT NULL DEFAULT '',
+			arrangement TEXT NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = DB.Exec(`
+		CREATE TABLE IF NOT EXISTS song_sections (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			label TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			text TEXT NOT NULL,
+			UNIQUE(song_id, label)
+		)
+	`)
+	return err
+}
+
+// GetSongs returns all songs ordered by title, without their sections
+func GetSongs() ([]*Song, error) {
+	rows, err := DB.Query(`
+		SELECT id, title, author, ccli, arrangement
+		FROM songs
+		ORDER BY title COLLATE NOCASE
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []*Song
+	for rows.Next() {
+		var (
+			s           Song
+			arrangement string
+		)
+		if err := rows.Scan(&s.ID, &s.Title, &s.Author, &s.CCLI, &arrangement); err != nil {
+			return nil, err
+		}
+		s.Arrangement = strings.Fields(arrangement)
+		songs = append(songs, &s)
+	}
+
+	return songs, rows.Err()
+}
+
+// GetSong returns a song with its sections
+func GetSong(id int) (*Song, error) {
+	var (
+		s           Song
+		arrangement string
+	)
+	err := DB.QueryRow(`
+		SELECT id, title, author, ccli, arrangement
+		FROM songs
+		WHERE id = ?
+	`, id).Scan(&s.ID, &s.Title, &s.Author, &s.CCLI, &arrangement)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("song not found: %d", id)
+		}
+		return nil, err
+	}
+	s.Arrangement = strings.Fields(arrangement)
+
+	rows, err := DB.Query(`
+		SELECT label, kind, text
+		FROM song_sections
+		WHERE song_id = ?
+		ORDER BY position
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var section Section
+		if err := rows.Scan(&section.Label, &section.Kind, &section.Text); err != nil {
+			return nil, err
+		}
+		s.Sections = append(s.Sections, &section)
+	}
+
+	return &s, rows.Err()
+}
+
+// SaveSong inserts a new song, or replaces it if it already has an ID.
+// The song's ID is set once the new song is committed.
+func SaveSong(song *Song) (err error) {
+	if song.Title == "" {
+		return fmt.Errorf("song has no title")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	id := song.ID
+	arrangement := strings.Join(song.Arrangement, " ")
+	if id > 0 {
+		_, err = tx.Exec(`
+			UPDATE songs SET title = ?, author = ?, ccli = ?, arrangement = ?
+			WHERE id = ?
+		`, song.Title, song.Author, song.CCLI, arrangement, id)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec("DELETE FROM song_sections WHERE song_id = ?", id); err != nil {
+			return err
+		}
+	} else {
+		var result sql.Result
+		result, err = tx.Exec(`
+			INSERT INTO songs (title, author, ccli, arrangement)
+			VALUES (?, ?, ?, ?)
+		`, song.Title, song.Author, song.CCLI, arrangement)
+		if err != nil {
+			return err
+		}
+		var inserted int64
+		inserted, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = int(inserted)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO song_sections (song_id, position, label, kind, text)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, section := range song.Sections {
+		if _, err = stmt.Exec(id, i, section.Label, section.Kind, section.Text); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	song.ID = id
+	return nil
+}
+
+// DeleteSong removes a song and its sections
+func DeleteSong(id int) (err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec("DELETE FROM song_sections WHERE song_id = ?", id); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM songs WHERE id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,350 @@
+package songs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mr-ministry/mr-verse/internal/xmltext"
+)
+
+// ImportFile reads and parses a song file.
+// The format is chosen by the file extension, see Parse.
+func ImportFile(path string) (*Song, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, filepath.Base(path))
+}
+
+// Parse parses song data, choosing the format by the file name:
+// .xml is OpenLyrics, .cho, .chopro, .chordpro and .crd are ChordPro,
+// anything else is plain text.
+func Parse(data []byte, filename string) (*Song, error) {
+	var (
+		song *Song
+		err  error
+	)
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xml":
+		song, err = ParseOpenLyrics(data)
+	case ".cho", ".chopro", ".chordpro", ".crd":
+		song, err = ParseChordPro(data)
+	default:
+		song, err = ParsePlainText(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if song.Title == "" {
+		song.Title = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	if len(song.Sections) == 0 {
+		return nil, fmt.Errorf("no lyrics found in %s", filename)
+	}
+	return song, nil
+}
+
+// ParsePlainText parses a plain text song.
+// The first line is the title, optionally followed by "Author:" and "CCLI:" lines.
+// Sections are separated by blank lines and may start with a heading such as
+// "Verse 1", "[Chorus]" or "Bridge:". A heading on its own repeats that section.
+// Sections without a heading are treated as verses.
+func ParsePlainText(data []byte) (*Song, error) {
+	song := &Song{}
+	blocks := splitBlocks(string(data))
+	if len(blocks) == 0 {
+		return song, nil
+	}
+
+	// The first block holds the title and metadata
+	header := blocks[0]
+	if _, _, isHeading := kindFromName(header[0]); !isHeading {
+		song.Title = strings.TrimSpace(header[0])
+		var rest []string
+		for _, line := range header[1:] {
+			if !song.parseMetadata(line) {
+				rest = append(rest, line)
+			}
+		}
+		if len(rest) > 0 {
+			blocks[0] = rest
+		} else {
+			blocks = blocks[1:]
+		}
+	}
+
+	for _, block := range blocks {
+		kind, number, isHeading := kindFromName(block[0])
+		if !isHeading {
+			section := song.addSection(KindVerse, strings.Join(block, "\n"))
+			song.Arrangement = append(song.Arrangement, section.Label)
+			continue
+		}
+
+		section := song.namedSection(kind, number, strings.Join(block[1:], "\n"))
+		if section != nil {
+			song.Arrangement = append(song.Arrangement, section.Label)
+		}
+	}
+
+	return song, nil
+}
+
+// parseMetadata reads an "Author:" or "CCLI:" line into the song.
+// Returns false when the line is not metadata.
+func (s *Song) parseMetadata(line string) bool {
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "title":
+		s.Title = value
+	case "author", "artist", "words", "composer":
+		s.Author = value
+	case "ccli", "ccli number", "ccli #", "ccli song":
+		s.CCLI = strings.TrimPrefix(value, "#")
+	default:
+		return false
+	}
+	return true
+}
+
+// namedSection returns the section for a heading, creating it when it has text.
+// A heading without text repeats an earlier section of that kind. When the
+// heading's label is already taken by other text, the next free label is used.
+func (s *Song) namedSection(kind SectionKind, number, text string) *Section {
+	if number != "" {
+		existing := s.Section(sectionPrefixes[kind] + number)
+		if existing != nil && (text == "" || text == existing.Text) {
+			return existing
+		}
+		if existing != nil {
+			// The number was taken by an unnumbered section before it
+			return s.addSection(kind, text)
+		}
+		if text == "" {
+			return nil
+		}
+		section := &Section{Label: sectionPrefixes[kind] + number, Kind: kind, Text: text}
+		s.Sections = append(s.Sections, section)
+		return section
+	}
+
+	// Without a number, repeat the latest section of that kind, or a chorus
+	// written out again in full
+	var latest *Section
+	for _, section := range s.Sections {
+		if section.Kind == kind {
+			latest = section
+			if kind != KindVerse && section.Text == text {
+				return section
+			}
+		}
+	}
+	if text == "" {
+		return latest
+	}
+	return s.addSection(kind, text)
+}
+
+// splitBlocks splits text into blocks of non-empty lines separated by blank lines
+func splitBlocks(text string) [][]string {
+	var (
+		blocks [][]string
+		block  []string
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// openLyricsSong is the subset of the OpenLyrics XML format that is imported
+type openLyricsSong struct {
+	Titles     []string `xml:"properties>titles>title"`
+	Authors    []string `xml:"properties>authors>author"`
+	CCLINo     string   `xml:"properties>ccliNo"`
+	VerseOrder string   `xml:"properties>verseOrder"`
+	Verses     []struct {
+		Name  string `xml:"name,attr"`
+		Lang  string `xml:"lang,attr"`
+		Lines []struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"lines"`
+	} `xml:"lyrics>verse"`
+}
+
+// openLyricsComments matches comments inside OpenLyrics lines
+var openLyricsComments = regexp.MustCompile(`(?s)<comment>.*?</comment>`)
+
+// openLyricsTags matches the markup inside OpenLyrics lines, such as chords and comments
+var openLyricsTags = regexp.MustCompile(`<[^>]*>`)
+
+// openLyricsBreaks matches line breaks inside OpenLyrics lines: <br/>, or the
+// end of a <line> element in OpenLyrics 0.7 and later
+var openLyricsBreaks = regexp.MustCompile(`<br\s*/?>|</line\s*>`)
+
+// openLyricsSpace matches whitespace in OpenLyrics lines, which is not significant
+var openLyricsSpace = regexp.MustCompile(`\s+`)
+
+// ParseOpenLyrics parses an OpenLyrics XML song (https://docs.openlyrics.org)
+func ParseOpenLyrics(data []byte) (*Song, error) {
+	var ol openLyricsSong
+	if err := xml.Unmarshal(data, &ol); err != nil {
+		return nil, fmt.Errorf("invalid OpenLyrics XML: %w", err)
+	}
+
+	song := &Song{CCLI: strings.TrimSpace(ol.CCLINo)}
+	if len(ol.Titles) > 0 {
+		song.Title = strings.TrimSpace(ol.Titles[0])
+	}
+	song.Author = strings.Join(ol.Authors, ", ")
+
+	langs := make(map[string]string) // Language of each section by label
+	for _, verse := range ol.Verses {
+		var lines []string
+		for _, l := range verse.Lines {
+			text := openLyricsComments.ReplaceAllString(l.Inner, "")
+			text = openLyricsSpace.ReplaceAllString(text, " ")
+			text = openLyricsBreaks.ReplaceAllString(text, "\n")
+			text = openLyricsTags.ReplaceAllString(text, "")
+			for _, line := range strings.Split(xmltext.Unescape(text), "\n") {
+				lines = append(lines, strings.TrimSpace(line))
+			}
+		}
+		text := strings.TrimSpace(strings.Join(lines, "\n"))
+
+		label := strings.ToLower(verse.Name)
+		kind := kindFromLabel(label)
+		existing := song.Section(label)
+		switch {
+		case label == "":
+			song.addSection(kind, text)
+		case existing != nil && langs[label] != verse.Lang:
+			// The same section in another language is shown along with it
+			existing.Text += "\n\n" + text
+		case existing != nil:
+			song.addSection(kind, text)
+		default:
+			song.Sections = append(song.Sections, &Section{Label: label, Kind: kind, Text: text})
+			langs[label] = verse.Lang
+		}
+	}
+
+	song.Arrangement = strings.Fields(strings.ToLower(ol.VerseOrder))
+	return song, nil
+}
+
+// chordProDirective matches a ChordPro directive such as {title: Amazing Grace}
+var chordProDirective = regexp.MustCompile(`^\{\s*([a-zA-Z_]+)\s*(?::\s*(.*?))?\s*\}$`)
+
+// chordProChord matches an inline chord such as [G] or [Am7]
+var chordProChord = regexp.MustCompile(`\[[^\]]*\]`)
+
+// ParseChordPro parses a ChordPro song, dropping chords.
+// Sections come from {start_of_*} environments, {comment: Verse 1} headings
+// or blank lines, and {chorus} on its own repeats the chorus.
+func ParseChordPro(data []byte) (*Song, error) {
+	song := &Song{}
+
+	var (
+		kind          = KindVerse
+		number        string
+		lines         []string
+		inEnvironment bool
+	)
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(lines, "\n"))
+		lines = nil
+		if text != "" {
+			section := song.namedSection(kind, number, text)
+			song.Arrangement = append(song.Arrangement, section.Label)
+		}
+		kind, number, inEnvironment = KindVerse, "", false
+	}
+	startEnvironment := func(k SectionKind) {
+		flush()
+		kind, inEnvironment = k, true
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue // ChordPro comment
+		}
+
+		if m := chordProDirective.FindStringSubmatch(line); m != nil {
+			name, value := strings.ToLower(m[1]), m[2]
+			switch name {
+			case "title", "t":
+				song.Title = value
+			case "artist", "composer", "lyricist":
+				if song.Author == "" {
+					song.Author = value
+				}
+			case "ccli":
+				song.CCLI = value
+			case "start_of_chorus", "soc":
+				startEnvironment(KindChorus)
+			case "start_of_bridge", "sob":
+				startEnvironment(KindBridge)
+			case "start_of_verse", "sov":
+				startEnvironment(KindVerse)
+			case "end_of_chorus", "eoc", "end_of_bridge", "eob", "end_of_verse", "eov":
+				flush()
+			case "chorus":
+				flush()
+				if chorus := song.namedSection(KindChorus, "", ""); chorus != nil {
+					song.Arrangement = append(song.Arrangement, chorus.Label)
+				}
+			case "comment", "c", "comment_italic", "ci":
+				if k, n, ok := kindFromName(value); ok {
+					flush()
+					kind, number = k, n
+				}
+			}
+			continue
+		}
+
+		if line == "" {
+			if !inEnvironment {
+				flush()
+			}
+			continue
+		}
+
+		text := strings.Join(strings.Fields(chordProChord.ReplaceAllString(line, "")), " ")
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+	flush()
+
+	return song, scanner.Err()
+}
@@ -0,0 +1,254 @@
+package songs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// section is a section label and text expected from an importer
+type section struct {
+	label, text string
+}
+
+// checkSong compares a parsed song with the expected sections and arrangement,
+// and checks that section labels are unique as the database requires
+func checkSong(t *testing.T, song *Song, sections []section, arrangement string) {
+	t.Helper()
+
+	var got []section
+	labels := make(map[string]bool)
+	for _, s := range song.Sections {
+		got = append(got, section{s.Label, s.Text})
+		if labels[strings.ToLower(s.Label)] {
+			t.Errorf("label %s is used twice", s.Label)
+		}
+		labels[strings.ToLower(s.Label)] = true
+	}
+	if !reflect.DeepEqual(got, sections) {
+		t.Errorf("sections = %q, want %q", got, sections)
+	}
+	if want := strings.Fields(arrangement); !reflect.DeepEqual(song.Arrangement, want) {
+		t.Errorf("arrangement = %q, want %q", song.Arrangement, want)
+	}
+}
+
+func TestParsePlainText(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		title       string
+		author      string
+		ccli        string
+		sections    []section
+		arrangement string
+	}{
+		{
+			name: "headings and metadata",
+			text: "Amazing Grace\nAuthor: John Newton\nCCLI: #22025\n\n" +
+				"Verse 1\nAmazing grace\nHow sweet the sound\n\n[Chorus]\nMy chains are gone\n\n" +
+				"Verse 2:\n'Twas grace\n\nChorus\n",
+			title:  "Amazing Grace",
+			author: "John Newton",
+			ccli:   "22025",
+			sections: []section{
+				{"v1", "Amazing grace\nHow sweet the sound"},
+				{"c1", "My chains are gone"},
+				{"v2", "'Twas grace"},
+			},
+			arrangement: "v1 c1 v2 c1",
+		},
+		{
+			name:        "blocks without headings are verses",
+			text:        "Title\n\nFirst\n\nSecond",
+			title:       "Title",
+			sections:    []section{{"v1", "First"}, {"v2", "Second"}},
+			arrangement: "v1 v2",
+		},
+		{
+			name:        "chorus written out again",
+			text:        "Title\n\nChorus\nSing\n\nVerse\nWords\n\nChorus\nSing",
+			title:       "Title",
+			sections:    []section{{"c1", "Sing"}, {"v1", "Words"}},
+			arrangement: "c1 v1 c1",
+		},
+		{
+			name:        "numbered heading after an unnumbered block",
+			text:        "Title\n\nFirst\n\nVerse 1\nExplicit",
+			title:       "Title",
+			sections:    []section{{"v1", "First"}, {"v2", "Explicit"}},
+			arrangement: "v1 v2",
+		},
+		{
+			name:        "unnumbered block after a numbered heading",
+			text:        "Title\n\nVerse 2\nSecond\n\nUntitled",
+			title:       "Title",
+			sections:    []section{{"v2", "Second"}, {"v3", "Untitled"}},
+			arrangement: "v2 v3",
+		},
+		{
+			name:        "numbered heading repeated without text",
+			text:        "Title\n\nVerse 1\nWords\n\nBridge\nHigher\n\nVerse 1",
+			title:       "Title",
+			sections:    []section{{"v1", "Words"}, {"b1", "Higher"}},
+			arrangement: "v1 b1 v1",
+		},
+		{
+			name:        "no title",
+			text:        "Verse 1\nWords",
+			sections:    []section{{"v1", "Words"}},
+			arrangement: "v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			song, err := ParsePlainText([]byte(tt.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if song.Title != tt.title || song.Author != tt.author || song.CCLI != tt.ccli {
+				t.Errorf("title, author, CCLI = %q, %q, %q; want %q, %q, %q",
+					song.Title, song.Author, song.CCLI, tt.title, tt.author, tt.ccli)
+			}
+			checkSong(t, song, tt.sections, tt.arrangement)
+		})
+	}
+}
+
+func TestParseChordPro(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		sections    []section
+		arrangement string
+	}{
+		{
+			name: "environments and chorus",
+			text: "{title: Amazing Grace}\n{artist: John Newton}\n# A comment\n\n" +
+				"{start_of_verse}\n[G]Amazing [C]grace\n\nHow sweet\n{end_of_verse}\n\n" +
+				"{soc}\nMy [D]chains are gone\n{eoc}\n\n{comment: Verse 2}\n'Twas grace\n\n{chorus}\n",
+			sections: []section{
+				{"v1", "Amazing grace\nHow sweet"},
+				{"c1", "My chains are gone"},
+				{"v2", "'Twas grace"},
+			},
+			arrangement: "v1 c1 v2 c1",
+		},
+		{
+			name:        "numbered comment after an unnumbered verse",
+			text:        "{c: Verse 1}\nFirst\n\nSecond\n\n{c: Verse 2}\nThird",
+			sections:    []section{{"v1", "First"}, {"v2", "Second"}, {"v3", "Third"}},
+			arrangement: "v1 v2 v3",
+		},
+		{
+			name:        "bridge",
+			text:        "{sob}\nHigher\n{eob}",
+			sections:    []section{{"b1", "Higher"}},
+			arrangement: "b1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			song, err := ParseChordPro([]byte(tt.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSong(t, song, tt.sections, tt.arrangement)
+		})
+	}
+}
+
+func TestParseOpenLyrics(t *testing.T) {
+	const head = `<?xml version="1.0" encoding="UTF-8"?>
+<song xmlns="http://openlyrics.info/namespace/2009/song" version="0.9">
+<properties><titles><title>Amazing Grace</title></titles>
+<authors><author>John Newton</author></authors><ccliNo>22025</ccliNo>%s</properties>
+<lyrics>`
+
+	tests := []struct {
+		name        string
+		verseOrder  string
+		lyrics      string
+		sections    []section
+		arrangement string
+	}{
+		{
+			name:       "line breaks, chords and comments",
+			verseOrder: "<verseOrder>v1 c v1</verseOrder>",
+			lyrics: `<verse name="v1"><lines>Amazing <chord name="G"/>grace<br/>How sweet &amp; clear<comment>slowly</comment></lines></verse>
+<verse name="c"><lines>My chains<br />are gone</lines></verse>`,
+			sections:    []section{{"v1", "Amazing grace\nHow sweet & clear"}, {"c", "My chains\nare gone"}},
+			arrangement: "v1 c v1",
+		},
+		{
+			name: "line elements",
+			lyrics: `<verse name="v1"><lines>
+  <line>Amazing   grace</line>
+  <line><chord name="D"/>How sweet</line>
+</lines></verse>`,
+			sections: []section{{"v1", "Amazing grace\nHow sweet"}},
+		},
+		{
+			name: "languages of a verse are shown together",
+			lyrics: `<verse name="v1" lang="en"><lines>Amazing grace</lines></verse>
+<verse name="v1" lang="de"><lines>Erstaunliche Gnade</lines></verse>`,
+			sections: []section{{"v1", "Amazing grace\n\nErstaunliche Gnade"}},
+		},
+		{
+			name: "repeated and missing names get free labels",
+			lyrics: `<verse name="v1"><lines>First</lines></verse>
+<verse name="V1"><lines>Again</lines></verse>
+<verse><lines>Unnamed</lines></verse>`,
+			sections: []section{{"v1", "First"}, {"v2", "Again"}, {"v3", "Unnamed"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(head, "%s", tt.verseOrder, 1) + tt.lyrics + "</lyrics></song>"
+			song, err := ParseOpenLyrics([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if song.Title != "Amazing Grace" || song.Author != "John Newton" || song.CCLI != "22025" {
+				t.Errorf("title, author, CCLI = %q, %q, %q", song.Title, song.Author, song.CCLI)
+			}
+			checkSong(t, song, tt.sections, tt.arrangement)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		filename string
+		data     string
+		title    string
+		wantErr  bool
+	}{
+		{filename: "Amazing Grace.txt", data: "Verse 1\nAmazing grace", title: "Amazing Grace"},
+		{filename: "song.cho", data: "{title: Be Thou My Vision}\nBe thou my vision", title: "Be Thou My Vision"},
+		{filename: "song.xml", data: "<song><lyrics><verse name=\"v1\"><lines>Words</lines></verse></lyrics></song>", title: "song"},
+		{filename: "empty.txt", data: "\n\n", wantErr: true},
+		{filename: "broken.xml", data: "<song>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			song, err := Parse([]byte(tt.data), tt.filename)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse(%q) succeeded, want an error", tt.filename)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if song.Title != tt.title {
+				t.Errorf("title = %q, want %q", song.Title, tt.title)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Package songs manages the song lyrics library and its importers
+package songs
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// SectionKind is the kind of a song section
+type SectionKind string
+
+// Section kinds
+const (
+	KindVerse     SectionKind = "verse"
+	KindChorus    SectionKind = "chorus"
+	KindPreChorus SectionKind = "prechorus"
+	KindBridge    SectionKind = "bridge"
+	KindTag       SectionKind = "tag"
+	KindIntro     SectionKind = "intro"
+	KindEnding    SectionKind = "ending"
+)
+
+// sectionPrefixes maps the OpenLyrics-style label prefix of each kind
+var sectionPrefixes = map[SectionKind]string{
+	KindVerse:     "v",
+	KindChorus:    "c",
+	KindPreChorus: "p",
+	KindBridge:    "b",
+	KindTag:       "t",
+	KindIntro:     "i",
+	KindEnding:    "e",
+}
+
+// sectionNames maps each kind to its display name
+var sectionNames = map[SectionKind]string{
+	KindVerse:     "Verse",
+	KindChorus:    "Chorus",
+	KindPreChorus: "Pre-Chorus",
+	KindBridge:    "Bridge",
+	KindTag:       "Tag",
+	KindIntro:     "Intro",
+	KindEnding:    "Ending",
+}
+
+// Song represents a song with its lyrics sections and arrangement
+type Song struct {
+	ID          int
+	Title       string
+	Author      string
+	CCLI        string
+	Sections    []*Section
+	Arrangement []string // Section labels in the order they are sung
+}
+
+// Section represents a tagged part of a song's lyrics
+type Section struct {
+	Label string // Short label such as "v1", "c1" or "b1"
+	Kind  SectionKind
+	Text  string
+}
+
+// DisplayName returns a human friendly name such as "Verse 1" or "Chorus"
+func (s *Section) DisplayName() string {
+	name, ok := sectionNames[s.Kind]
+	if !ok {
+		return s.Label
+	}
+
+	number := strings.TrimLeftFunc(s.Label, unicode.IsLetter)
+	if number == "" || (s.Kind != KindVerse && number == "1") {
+		return name
+	}
+	return name + " " + number
+}
+
+// Section returns the section with the given label, or nil if there is none
+func (s *Song) Section(label string) *Section {
+	for _, section := range s.Sections {
+		if strings.EqualFold(section.Label, label) {
+			return section
+		}
+	}
+	return nil
+}
+
+// ArrangedSections returns the sections in arrangement order.
+// Without an arrangement, sections are returned in the order they were written.
+func (s *Song) ArrangedSections() []*Section {
+	if len(s.Arrangement) == 0 {
+		return s.Sections
+	}
+
+	var sections []*Section
+	for _, label := range s.Arrangement {
+		if section := s.Section(label); section != nil {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// Credits returns the footer line crediting the song's author and CCLI number
+func (s *Song) Credits() string {
+	var parts []string
+	if s.Author != "" {
+		parts = append(parts, s.Author)
+	}
+	if s.CCLI != "" {
+		parts = append(parts, "CCLI #"+s.CCLI)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Slides returns the song's sections as slides in arrangement order
+func (s *Song) Slides() []*presentation.Slide {
+	sections := s.ArrangedSections()
+	slides := make([]*presentation.Slide, 0, len(sections))
+	for _, section := range sections {
+		slides = append(slides, &presentation.Slide{
+			Title:  s.Title,
+			Body:   section.Text,
+			Footer: s.Credits(),
+		})
+	}
+	return slides
+}
+
+// addSection appends a section of the given kind, numbering it after
+// the existing sections of the same kind, and returns it. Numbers taken by
+// explicitly numbered headings are skipped, since labels must be unique.
+func (s *Song) addSection(kind SectionKind, text string) *Section {
+	count := 1
+	for _, section := range s.Sections {
+		if section.Kind == kind {
+			count++
+		}
+	}
+
+	section := &Section{
+		Label: s.unusedLabel(kind, count),
+		Kind:  kind,
+		Text:  text,
+	}
+	s.Sections = append(s.Sections, section)
+	return section
+}
+
+// unusedLabel returns the first label of a kind, from number on, that no section has
+func (s *Song) unusedLabel(kind SectionKind, number int) string {
+	for {
+		label := fmt.Sprintf("%s%d", sectionPrefixes[kind], number)
+		if s.Section(label) == nil {
+			return label
+		}
+		number++
+	}
+}
+
+// kindFromLabel returns the section kind for a label such as "v1" or "c"
+func kindFromLabel(label string) SectionKind {
+	prefix := strings.ToLower(strings.TrimRightFunc(label, unicode.IsDigit))
+	for kind, p := range sectionPrefixes {
+		if prefix == p {
+			return kind
+		}
+	}
+	return KindVerse
+}
+
+// kindFromName returns the section kind for a heading such as "Verse 2" or "Chorus".
+// ok is false when the name is not a known section heading.
+func kindFromName(name string) (kind SectionKind, number string, ok bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Trim(name, "[]:")
+	fields := strings.Fields(name)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", "", false
+	}
+
+	word := strings.ReplaceAll(fields[0], "-", "")
+	if len(fields) == 2 {
+		number = fields[1]
+		if strings.TrimFunc(number, unicode.IsDigit) != "" {
+			return "", "", false
+		}
+	}
+
+	switch word {
+	case "verse":
+		return KindVerse, number, true
+	case "chorus", "refrain":
+		return KindChorus, number, true
+	case "prechorus":
+		return KindPreChorus, number, true
+	case "bridge":
+		return KindBridge, number, true
+	case "tag":
+		return KindTag, number, true
+	case "intro":
+		return KindIntro, number, true
+	case "ending", "outro":
+		return KindEnding, number, true
+	}
+	return "", "", false
+}
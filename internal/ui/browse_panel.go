@@ -0,0 +1,331 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// browsePanel lets the operator browse a translation book by book and chapter
+// by chapter, preview verses and show them without typing a reference
+type browsePanel struct {
+	controller  *ControllerWindow
+	translation string
+	books       []string
+	bookNames   []string // Localized display names, aligned with books
+	book        string
+	chapters    []int
+	chapter     int
+	verses      []*bible.Verse
+	headings    map[int]string // Section headings of the chapter by verse
+	previewed   *bible.Verse
+	live        *bible.Verse
+	bookList    *widget.List
+	chapterList *widget.List
+	verseList   *widget.List
+	preview     *widget.Label
+}
+
+// newBrowsePanel creates the chapter browser for the controller window
+func newBrowsePanel(c *ControllerWindow) *browsePanel {
+	return &browsePanel{
+		controller: c,
+	}
+}
+
+// content builds the chapter browser UI
+func (p *browsePanel) content() fyne.CanvasObject {
+	p.bookList = widget.NewList(
+		func() int {
+			return len(p.books)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("1st Thessalonians")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(p.bookNames[id])
+		},
+	)
+	p.bookList.OnSelected = func(id widget.ListItemID) {
+		p.selectBook(p.books[id])
+	}
+
+	p.chapterList = widget.NewList(
+		func() int {
+			return len(p.chapters)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("150")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			label := item.(*widget.Label)
+			label.SetText(strconv.Itoa(p.chapters[id]))
+			label.Importance = widget.MediumImportance
+			if p.live != nil && p.live.Book == p.book && p.live.Chapter == p.chapters[id] {
+				label.Importance = widget.HighImportance
+			}
+			label.Refresh()
+		},
+	)
+	p.chapterList.OnSelected = func(id widget.ListItemID) {
+		p.selectChapter(p.chapters[id])
+	}
+
+	p.verseList = widget.NewList(
+		func() int {
+			return len(p.verses)
+		},
+		func() fyne.CanvasObject {
+			heading := widget.NewLabelWithStyle("Section heading", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			heading.Truncation = fyne.TextTruncateEllipsis
+			heading.Hide()
+			label := widget.NewLabel("176  Verse text")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return container.NewVBox(heading, label)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			verse := p.verses[id]
+			row := item.(*fyne.Container)
+			heading := row.Objects[0].(*widget.Label)
+			if text := p.headings[verse.Verse]; text != "" && p.showHeadings() {
+				heading.SetText(text)
+				heading.Show()
+			} else {
+				heading.Hide()
+			}
+
+			label := row.Objects[1].(*widget.Label)
+			label.SetText(fmt.Sprintf("%d  %s", verse.Verse, verse.Text))
+			label.Importance = widget.MediumImportance
+			if p.isLive(verse) {
+				label.Importance = widget.HighImportance
+			}
+			label.Refresh()
+		},
+	)
+	p.verseList.OnSelected = func(id widget.ListItemID) {
+		p.previewVerse(p.verses[id])
+	}
+
+	p.preview = widget.NewLabel("Select a verse to preview it")
+	p.preview.Wrapping = fyne.TextWrapWord
+
+	showButton := widget.NewButton("Show Verse", func() {
+		p.showPreviewed()
+	})
+	liveButton := widget.NewButton("Go to Live Verse", func() {
+		p.revealLive()
+	})
+	exportButton := widget.NewButton("Export Translation", func() {
+		p.controller.showExportTranslationDialog()
+	})
+	verifyButton := widget.NewButton("Verify Translation", func() {
+		p.controller.showVerifyDialog()
+	})
+
+	lists := container.NewHSplit(
+		container.NewBorder(widget.NewLabel("Books:"), nil, nil, nil, p.bookList),
+		container.NewHSplit(
+			container.NewBorder(widget.NewLabel("Chapters:"), nil, nil, nil, p.chapterList),
+			container.NewBorder(widget.NewLabel("Verses:"), nil, nil, nil, p.verseList),
+		),
+	)
+	lists.SetOffset(0.3)
+
+	return container.NewBorder(
+		nil,
+		container.NewVBox(
+			p.preview,
+			container.NewGridWithColumns(2, showButton, liveButton, exportButton, verifyButton),
+		),
+		nil,
+		nil,
+		lists,
+	)
+}
+
+// setTranslation loads the books of a translation into the browser
+func (p *browsePanel) setTranslation(translation string) {
+	if translation == p.translation {
+		return
+	}
+
+	books, err := bible.GetBooks(translation)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load books: %w", err), p.controller.window)
+		return
+	}
+
+	names := make([]string, len(books))
+	for i, book := range books {
+		name, err := bible.GetLocalizedBookName(translation, book)
+		if err != nil || name == "" {
+			name = book
+		}
+		names[i] = name
+	}
+
+	book, chapter := p.book, p.chapter
+	p.translation = translation
+	p.books = books
+	p.bookNames = names
+	p.book = ""
+	p.chapters = nil
+	p.verses = nil
+	p.bookList.UnselectAll()
+	p.bookList.Refresh()
+	p.chapterList.Refresh()
+	p.verseList.Refresh()
+
+	// Stay in the same chapter when switching translations
+	if book != "" {
+		p.showChapter(book, chapter)
+	}
+}
+
+// selectBook loads the chapters of a book
+func (p *browsePanel) selectBook(book string) {
+	if book == p.book {
+		return
+	}
+
+	chapters, err := bible.GetChapters(p.translation, book)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load chapters: %w", err), p.controller.window)
+		return
+	}
+
+	p.book = book
+	p.chapters = chapters
+	p.chapter = 0
+	p.verses = nil
+	p.chapterList.UnselectAll()
+	p.chapterList.Refresh()
+	p.verseList.UnselectAll()
+	p.verseList.Refresh()
+}
+
+// selectChapter loads the verses of a chapter in the selected book
+func (p *browsePanel) selectChapter(chapter int) {
+	if chapter == p.chapter {
+		return
+	}
+
+	verses, err := bible.GetChapterVerses(p.translation, p.book, chapter)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load verses: %w", err), p.controller.window)
+		return
+	}
+	headings, err := bible.GetSectionHeadings(p.translation, p.book, chapter)
+	if err != nil {
+		slog.Warn("Failed to load section headings", logging.Translation(p.translation), "book", p.book, "chapter", chapter, "error", err)
+	}
+
+	p.chapter = chapter
+	p.verses = verses
+	p.headings = headings
+	p.verseList.UnselectAll()
+	p.refreshHeadings()
+	p.verseList.ScrollToTop()
+}
+
+// showHeadings returns whether section headings are shown
+func (p *browsePanel) showHeadings() bool {
+	return p.controller.app.Preferences().Bool(config.PrefKeyShowSectionHeadings)
+}
+
+// refreshHeadings makes room for the section headings above verses, or removes it
+func (p *browsePanel) refreshHeadings() {
+	rowHeight := widget.NewLabel("176").MinSize().Height
+	for id, verse := range p.verses {
+		if p.headings[verse.Verse] != "" && p.showHeadings() {
+			p.verseList.SetItemHeight(id, 2*rowHeight+theme.Padding())
+		} else {
+			p.verseList.SetItemHeight(id, rowHeight)
+		}
+	}
+	p.verseList.Refresh()
+}
+
+// showChapter selects a book and chapter in the lists
+func (p *browsePanel) showChapter(book string, chapter int) {
+	for i, b := range p.books {
+		if b == book {
+			p.bookList.Select(i)
+			p.bookList.ScrollTo(i)
+			break
+		}
+	}
+	if p.book != book {
+		return // Book not in this translation
+	}
+
+	for i, c := range p.chapters {
+		if c == chapter {
+			p.chapterList.Select(i)
+			p.chapterList.ScrollTo(i)
+			break
+		}
+	}
+}
+
+// previewVerse shows a verse in the preview area without presenting it
+func (p *browsePanel) previewVerse(verse *bible.Verse) {
+	p.previewed = verse
+	reference := presentation.FormatReference(verse)
+	if section := presentation.SectionHeading(verse); section != "" && p.showHeadings() {
+		reference = fmt.Sprintf("%s (%s)", reference, section)
+	}
+	p.preview.SetText(fmt.Sprintf("%s\n%s", reference, verse.Text))
+}
+
+// showPreviewed presents the previewed verse
+func (p *browsePanel) showPreviewed() {
+	if p.previewed == nil {
+		dialog.ShowInformation("Error", "Please select a verse to show", p.controller.window)
+		return
+	}
+
+	p.controller.versePresentation.SetVerse(p.previewed)
+}
+
+// revealLive opens the chapter of the live verse and scrolls to it
+func (p *browsePanel) revealLive() {
+	if p.live == nil {
+		dialog.ShowInformation("Error", "No verse is being shown", p.controller.window)
+		return
+	}
+
+	p.showChapter(p.live.Book, p.live.Chapter)
+	for i, verse := range p.verses {
+		if p.isLive(verse) {
+			p.verseList.ScrollTo(i)
+			break
+		}
+	}
+}
+
+// setLive highlights the verse being shown, or clears the highlight when nil
+func (p *browsePanel) setLive(verse *bible.Verse) {
+	p.live = verse
+	p.chapterList.Refresh()
+	p.verseList.Refresh()
+}
+
+// isLive reports whether a verse is the one being shown
+func (p *browsePanel) isLive(verse *bible.Verse) bool {
+	return p.live != nil &&
+		p.live.Book == verse.Book &&
+		p.live.Chapter == verse.Chapter &&
+		p.live.Verse == verse.Verse
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/server"
+)
+
+// watchConfig reports a configuration file that couldn't be used at startup,
+// and applies the file whenever it changes. Changes are applied one at a time
+// until the returned function, which stops watching, returns.
+func (c *ControllerWindow) watchConfig() func() {
+	path := appdir.ConfigFile()
+	if err := config.LoadError(); err != nil {
+		dialog.ShowError(fmt.Errorf("%w\n\nThe default settings are used until the file is fixed.", err), c.window)
+	}
+
+	reloads, stop, err := config.Watch(path)
+	if err != nil {
+		slog.Warn("Could not watch configuration file; changes apply at the next start", "file", path, "error", err)
+		return func() {}
+	}
+
+	applied := make(chan struct{})
+	go func() {
+		defer close(applied)
+		for r := range reloads {
+			c.configChanged(r.File, r.Err)
+		}
+	}()
+
+	return func() {
+		if err := stop(); err != nil {
+			slog.Warn("Error closing configuration file watcher", "error", err)
+		}
+		<-applied
+	}
+}
+
+// configChanged applies a reloaded configuration file, or reports why it was
+// rejected. Paths and the log format take effect at the next start.
+func (c *ControllerWindow) configChanged(f *config.File, err error) {
+	if err != nil {
+		slog.Warn("Ignoring changed configuration file", "error", err)
+		dialog.ShowError(fmt.Errorf("%w\n\nThe previous settings are kept until the file is fixed.", err), c.window)
+		return
+	}
+
+	c.keymap.apply(f.Keymap)
+	themes := f.AddThemes()
+	if !config.IsFixed("logging.level") {
+		if level, err := logging.ParseLevel(f.Logging.Level); err == nil {
+			logging.SetLevel(level)
+		}
+	}
+	addr := config.HTTPAddr()
+	if err := c.startServer(addr, config.HTTPToken()); err != nil {
+		slog.Warn("Failed to restart HTTP server", "error", err)
+		dialog.ShowError(err, c.window)
+	}
+	if len(themes) > 0 {
+		c.refreshThemes()
+	}
+
+	slog.Info("Reloaded configuration file", "file", appdir.ConfigFile(),
+		"themes", strings.Join(themes, ", "), "level", logging.Level(), "http_addr", addr)
+}
+
+// refreshThemes lists the slide themes again in the theme selects of the tabs
+func (c *ControllerWindow) refreshThemes() {
+	for _, themeSelect := range []*widget.Select{c.slideEditor.themeSelect, c.mediaPanel.themeSelect} {
+		themeSelect.Options = presentation.ThemeNames()
+		themeSelect.Refresh()
+	}
+}
+
+// startServer serves the web stage display at addr, replacing the server that
+// was running unless it already serves addr with token. An empty address only
+// stops it. It is safe to call from any goroutine.
+func (c *ControllerWindow) startServer(addr, token string) error {
+	c.serverMutex.Lock()
+	defer c.serverMutex.Unlock()
+
+	if c.server != nil {
+		if addr == c.serverAddr && token == c.serverToken {
+			return nil
+		}
+		c.server.Stop()
+		c.server = nil
+	}
+	c.serverAddr, c.serverToken = "", ""
+	if addr == "" {
+		return nil
+	}
+
+	s := server.NewServer(
+		addr,
+		token,
+		c.versePresentation,
+		c.autoAdvance,
+		c.timer,
+	)
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("the web stage display is off: %w", err)
+	}
+	c.server, c.serverAddr, c.serverToken = s, addr, token
+	return nil
+}
@@ -3,7 +3,12 @@ package ui
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -12,7 +17,13 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/library"
+	"github.com/mr-ministry/mr-verse/internal/logging"
 	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/server"
+	"github.com/mr-ministry/mr-verse/internal/servicelog"
+	"github.com/mr-ministry/mr-verse/internal/songs"
 )
 
 // ControllerWindow represents the main control window
@@ -20,11 +31,35 @@ type ControllerWindow struct {
 	window            fyne.Window
 	app               fyne.App
 	liveWindow        *LiveWindow
+	stageWindow       *StageWindow
 	versePresentation *presentation.VersePresentation
-	searchEntry       *widget.Entry
+	autoAdvance       *presentation.AutoAdvance
+	timer             *presentation.Timer
+	messages          *presentation.MessageQueue
+	lobby             *presentation.Lobby
+	serviceLog        *servicelog.Recorder
+	searchEntry       *referenceEntry
 	translationSelect *widget.Select
 	statusLabel       *widget.Label
 	currentVerseLabel *widget.Label
+	followStatus      *widget.Label
+	slideEditor       *slideEditor
+	songPanel         *songPanel
+	historyPanel      *historyPanel
+	browsePanel       *browsePanel
+	timerPanel        *timerPanel
+	messagePanel      *messagePanel
+	mediaPanel        *mediaPanel
+	lobbyPanel        *lobbyPanel
+	translationPanel  *translationPanel
+	importProgress    *importProgress
+	keymap            *keymap
+
+	// serverMutex guards the web server, which configuration changes restart
+	serverMutex sync.Mutex
+	server      *server.Server
+	serverAddr  string
+	serverToken string
 }
 
 // RunApp initializes and runs the application
@@ -34,9 +69,10 @@ func RunApp() {
 	w := a.NewWindow("Mr Verse - Controller")
 	w.Resize(fyne.NewSize(800, 600))
 
-	// Initialize and seed the database
+	// Initialize the database
 	if err := initializeDatabases(w); err != nil {
-		log.Fatalf("Failed to initialize application: %v", err)
+		slog.Error("Failed to initialize application", "error", err)
+		os.Exit(1)
 	}
 
 	// Create the controller window
@@ -44,24 +80,55 @@ func RunApp() {
 		window:            w,
 		app:               a,
 		versePresentation: presentation.NewVersePresentation(),
+		timer:             presentation.NewTimer(),
+		messages:          presentation.NewMessageQueue(),
+		serviceLog:        servicelog.NewRecorder(),
+		importProgress:    newImportProgress(),
 	}
+	controller.autoAdvance = presentation.NewAutoAdvance(controller.versePresentation)
+	controller.lobby = presentation.NewLobby(controller.versePresentation)
+	controller.timer.SetOnExpire(controller.timerExpired)
 
 	// Create the live window
-	controller.liveWindow = NewLiveWindow(a, func() {
+	controller.liveWindow = NewLiveWindow(a, controller.timer, controller.messages, func() {
+		slog.Info("Closed live window", logging.Action("close live"))
 		controller.updateLiveWindowStatus(false)
+		controller.logLive(nil)
 	})
 
+	// Create the stage window
+	controller.stageWindow = NewStageWindow(a, controller.timer, nil)
+
+	// Serve the web stage display if an address is configured
+	if err := controller.startServer(config.HTTPAddr(), config.HTTPToken()); err != nil {
+		slog.Error("Failed to start HTTP server", "error", err)
+		dialog.ShowError(err, w)
+	}
+
 	// Set up the UI
 	controller.setupUI()
 
+	// Apply changes to the configuration file while running
+	stopWatching := controller.watchConfig()
+
+	// Import new translations in the background, so the ones already
+	// in the database can be used meanwhile
+	go controller.seedTranslations()
+
 	// Show the window
 	w.ShowAndRun()
 
 	// Clean up
+	controller.autoAdvance.Stop()
+	controller.lobby.Stop()
+	controller.logLive(nil)
+	stopWatching()
+	controller.startServer("", "")
 	bible.CloseDB()
 }
 
-// initializeDatabases initializes and seeds the Bible database
+// initializeDatabases initializes the Bible database. Translations are
+// seeded afterwards by seedTranslations.
 func initializeDatabases(w fyne.Window) error {
 	// Initialize the database
 	if err := bible.InitDB(); err != nil {
@@ -69,17 +136,28 @@ func initializeDatabases(w fyne.Window) error {
 		return err
 	}
 
-	// Seed the database with Bible data
-	if err := bible.SeedBibleData(); err != nil {
-		dialog.ShowError(fmt.Errorf("failed to seed Bible data: %w", err), w)
-		log.Printf("Failed to seed Bible data: %v", err)
-		// Not a fatal error, can continue
+	// Create the slide library tables
+	if err := library.Init(bible.DB); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to initialize slide library: %w", err), w)
+		return err
+	}
+
+	// Create the song library tables
+	if err := songs.Init(bible.DB); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to initialize song library: %w", err), w)
+		return err
+	}
+
+	// Create the service log table
+	if err := servicelog.Init(bible.DB); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to initialize service log: %w", err), w)
+		return err
 	}
 
-	// Seed chapter headers (runs safely even if verses already exist)
-	if err := bible.SeedChapterHeaders(); err != nil {
-		dialog.ShowError(fmt.Errorf("failed to seed chapter headers: %w", err), w)
-		log.Printf("Failed to seed chapter headers: %v", err)
+	// Seed the built-in book names and abbreviations
+	if err := bible.SeedBookAliases(); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to seed book names: %w", err), w)
+		slog.Error("Failed to seed book names", "error", err)
 		// Not a fatal error, can continue
 	}
 
@@ -88,12 +166,14 @@ func initializeDatabases(w fyne.Window) error {
 
 // setupUI sets up the user interface
 func (c *ControllerWindow) setupUI() {
-	// Create the search entry
-	c.searchEntry = widget.NewEntry()
+	// Create the search entry with reference suggestions
+	c.searchEntry = newReferenceEntry(c.window, c.suggestReferences, func(bible.Suggestion) {
+		c.searchVerse()
+	})
 	// c.searchEntry.SetPlaceHolder("Enter Bible reference (e.g., John 3:16)")
 
-	// c.searchEntry.SetText("Esther 8:9") // use this to set the default verse
-	c.searchEntry.SetText("John 3:16")
+	// The startup verse is set in the configuration file
+	c.searchEntry.SetText(config.Current().Bible.StartupVerse)
 	// Create the search button
 	searchButton := widget.NewButton("Search", func() {
 		c.searchVerse()
@@ -110,6 +190,12 @@ func (c *ControllerWindow) setupUI() {
 	// Load available translations
 	go c.loadTranslations()
 
+	// Import new translations from a file or dropped onto the window
+	importButton := widget.NewButton("Import Translation", func() {
+		c.showImportTranslationDialog()
+	})
+	c.window.SetOnDropped(c.dropFiles)
+
 	// Create the navigation buttons
 	prevButton := widget.NewButton("Previous Verse", func() {
 		c.navigateToPreviousVerse()
@@ -118,15 +204,18 @@ func (c *ControllerWindow) setupUI() {
 		c.navigateToNextVerse()
 	})
 
+	// Create the history navigation buttons
+	backButton := widget.NewButton("Back", func() {
+		c.navigateBack()
+	})
+	forwardButton := widget.NewButton("Forward", func() {
+		c.navigateForward()
+	})
+
 	// Create the live window control button
 	// TODO: Change the button color when the live window is open
 	liveWindowButton := widget.NewButton("Go Live", func() {
-		if c.liveWindow.IsOpen() {
-			c.liveWindow.Close()
-		} else {
-			c.liveWindow.Open()
-			c.updateLiveWindowStatus(true)
-		}
+		c.toggleLive()
 	})
 
 	// Create the update live window button
@@ -134,6 +223,45 @@ func (c *ControllerWindow) setupUI() {
 		c.updateLiveWindow()
 	})
 
+	// Create the stage display button
+	stageWindowButton := widget.NewButton("Stage Display", func() {
+		if c.stageWindow.IsOpen() {
+			c.stageWindow.Close()
+		} else {
+			c.stageWindow.Open()
+			c.stageWindow.UpdateSlide(c.versePresentation.GetSlide())
+		}
+	})
+
+	// Create the stage countdown controls
+	countdownEntry := widget.NewEntry()
+	countdownEntry.SetPlaceHolder("Minutes")
+	countdownEntry.SetText("5")
+	startCountdownButton := widget.NewButton("Start Countdown", func() {
+		c.startCountdown(countdownEntry.Text)
+	})
+	stopCountdownButton := widget.NewButton("Stop Countdown", func() {
+		c.timer.Stop()
+	})
+
+	// Create the section headings option
+	sectionCheck := widget.NewCheck("Show Section Headings", func(show bool) {
+		c.app.Preferences().SetBool(config.PrefKeyShowSectionHeadings, show)
+		c.browsePanel.refreshHeadings()
+		if c.liveWindow.IsOpen() {
+			c.liveWindow.UpdateSlide(c.versePresentation.GetSlide())
+		}
+	})
+	sectionCheck.Checked = c.app.Preferences().Bool(config.PrefKeyShowSectionHeadings)
+
+	// Create the image and playlist export buttons
+	exportImageButton := widget.NewButton("Export Image", func() {
+		c.showExportImageDialog()
+	})
+	exportPlaylistButton := widget.NewButton("Export Playlist", func() {
+		c.showExportPlaylistDialog()
+	})
+
 	// Create the settings button
 	// settingsButton := widget.NewButton("Settings", func() {
 	// 	c.showSettingsDialog()
@@ -149,21 +277,65 @@ func (c *ControllerWindow) setupUI() {
 	// Use a 2-column grid so button widths align across rows
 	buttons := container.NewGridWithColumns(2,
 		prevButton, nextButton,
+		backButton, forwardButton,
 		liveWindowButton, updateLiveButton,
 	)
 
+	stageControls := container.NewGridWithColumns(2,
+		stageWindowButton, countdownEntry,
+		startCountdownButton, stopCountdownButton,
+	)
+
 	controlsContainer := container.NewVBox(
 		widget.NewLabel("Bible Translation:"),
-		c.translationSelect,
+		container.NewBorder(nil, nil, nil, importButton, c.translationSelect),
 		buttons,
+		sectionCheck,
+		container.NewGridWithColumns(2, exportImageButton, exportPlaylistButton),
+		widget.NewLabel("Stage Display:"),
+		stageControls,
+		widget.NewLabel("Follow Along:"),
+		c.followControls(),
 		// settingsButton,
 	)
 
-	statusContainer := container.NewHBox(
-		widget.NewLabel("Status:"),
-		c.statusLabel,
-		widget.NewLabel("Current Verse:"),
-		c.currentVerseLabel,
+	statusContainer := container.NewBorder(nil, nil,
+		container.NewHBox(
+			widget.NewLabel("Status:"),
+			c.statusLabel,
+			widget.NewLabel("Current Verse:"),
+			c.currentVerseLabel,
+		),
+		container.NewHBox(
+			c.importProgress.content(),
+			widget.NewButton("Copy Diagnostics", func() {
+				c.copyDiagnostics()
+			}),
+		),
+	)
+
+	// Create the tab panels
+	c.browsePanel = newBrowsePanel(c)
+	c.timerPanel = newTimerPanel(c)
+	c.messagePanel = newMessagePanel(c)
+	c.mediaPanel = newMediaPanel(c)
+	c.lobbyPanel = newLobbyPanel(c)
+	c.slideEditor = newSlideEditor(c)
+	c.songPanel = newSongPanel(c)
+	c.historyPanel = newHistoryPanel(c)
+	c.translationPanel = newTranslationPanel(c)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Bible", container.New(layout.NewCenterLayout(), controlsContainer)),
+		container.NewTabItem("Browse", c.browsePanel.content()),
+		container.NewTabItem("Slides", c.slideEditor.content()),
+		container.NewTabItem("Songs", c.songPanel.content()),
+		container.NewTabItem("Timer", c.timerPanel.content()),
+		container.NewTabItem("Messages", c.messagePanel.content()),
+		container.NewTabItem("Media", c.mediaPanel.content()),
+		container.NewTabItem("Lobby", c.lobbyPanel.content()),
+		container.NewTabItem("History", c.historyPanel.content()),
+		container.NewTabItem("Translations", c.translationPanel.content()),
 	)
 
 	// Main layout
@@ -172,27 +344,48 @@ func (c *ControllerWindow) setupUI() {
 		statusContainer,
 		nil,
 		nil,
-		container.New(layout.NewCenterLayout(), controlsContainer),
+		tabs,
 	)
 
 	c.window.SetContent(mainContainer)
 
-	// Register as an observer for verse changes
-	c.versePresentation.AddObserver(func(verse *bible.Verse) {
-		if verse != nil {
-			c.updateCurrentVerseLabel(verse)
+	// Bind the keys of the configuration file to controller actions
+	c.keymap = newKeymap(c)
+	c.keymap.apply(config.Current().Keymap)
+	c.searchEntry.onKey = c.keymap.run
+
+	// Register as an observer for slide changes
+	c.versePresentation.AddObserver(func(slide *presentation.Slide) {
+		if slide != nil {
+			c.updateCurrentVerseLabel(slide)
+			c.historyPanel.refresh()
+			c.browsePanel.setLive(slide.Verse)
 
 			// Update the live window if it's open
 			if c.liveWindow.IsOpen() {
-				c.liveWindow.UpdateVerse(verse)
+				c.liveWindow.UpdateSlide(slide)
+				c.logLive(slide)
 			}
+
+			// Keep the stage display in sync
+			c.stageWindow.UpdateSlide(slide)
 		}
 	})
 }
 
-// loadTranslations loads the available Bible translations
+// loadTranslations loads the visible Bible translations in their display order
 func (c *ControllerWindow) loadTranslations() {
-	translations, err := bible.GetAvailableTranslations()
+	translations, err := bible.GetVisibleTranslations()
+	var defaultTranslation string
+	if err == nil {
+		defaultTranslation, err = bible.GetDefaultTranslation()
+	}
+	// The configuration file can choose another translation to start with
+	if configured := config.Current().Bible.DefaultTranslation; slices.Contains(translations, configured) {
+		defaultTranslation = configured
+	} else if configured != "" && len(translations) > 0 {
+		slog.Warn("Default translation of the configuration file is not available", logging.Translation(configured))
+	}
 	if err != nil {
 		// Use a goroutine to show the error dialog on the main thread
 		go func() {
@@ -208,7 +401,10 @@ func (c *ControllerWindow) loadTranslations() {
 	go func() {
 		if len(translations) > 0 {
 			c.translationSelect.Options = translations
-			c.translationSelect.SetSelected(translations[0])
+			// Keep the selection when reloading after an import or a change of settings
+			if !slices.Contains(translations, c.translationSelect.Selected) {
+				c.translationSelect.SetSelected(defaultTranslation)
+			}
 		} else {
 			c.translationSelect.Options = []string{"No translations available"}
 		}
@@ -216,8 +412,42 @@ func (c *ControllerWindow) loadTranslations() {
 	}()
 }
 
+// translationsChanged reloads the translation list and the translation manager
+// after translations are imported or their settings change
+func (c *ControllerWindow) translationsChanged() {
+	c.loadTranslations()
+	c.translationPanel.refresh()
+}
+
+// suggestReferences returns the reference suggestions for typed text
+// in the selected translation
+func (c *ControllerWindow) suggestReferences(text string) ([]bible.Suggestion, error) {
+	if c.translationSelect == nil {
+		return nil, nil // Still setting up the UI
+	}
+
+	translation := c.translationSelect.Selected
+	if translation == "" || translation == "Loading..." ||
+		translation == "No translations available" {
+		return nil, nil
+	}
+
+	return bible.SuggestReferences(translation, text, maxSuggestions)
+}
+
+// referenceLanguage returns the language of the selected translation,
+// whose book names win when a typed reference is parsed
+func (c *ControllerWindow) referenceLanguage() string {
+	language, err := bible.GetTranslationLanguage(c.translationSelect.Selected)
+	if err != nil {
+		slog.Warn("Failed to get translation language", logging.Translation(c.translationSelect.Selected), "error", err)
+		return bible.DefaultLanguage
+	}
+	return language
+}
+
 // searchVerse searches for a Bible verse
-// TODO: Allow for fuzzy search using keywords from verse reference or verse text
+// TODO: Allow searching using keywords from the verse text
 func (c *ControllerWindow) searchVerse() {
 	reference := c.searchEntry.Text
 	if reference == "" {
@@ -225,8 +455,8 @@ func (c *ControllerWindow) searchVerse() {
 		return
 	}
 
-	// Parse the reference
-	book, chapter, verse, err := bible.ParseBibleReference(reference)
+	// Parse the reference, preferring book names in the selected translation's language
+	book, chapter, verse, err := bible.ParseBibleReferenceIn(reference, c.referenceLanguage())
 	if err != nil {
 		dialog.ShowError(fmt.Errorf("invalid Bible reference: %w", err), c.window)
 		return
@@ -243,9 +473,12 @@ func (c *ControllerWindow) searchVerse() {
 	// Fetch and set the verse
 	err = c.versePresentation.FetchAndSetVerse(translation, book, chapter, verse)
 	if err != nil {
+		slog.Warn("Failed to fetch verse", logging.Action("search"), logging.Translation(translation),
+			logging.Reference(book, chapter, verse), "error", err)
 		dialog.ShowError(fmt.Errorf("failed to fetch verse: %w", err), c.window)
 		return
 	}
+	slog.Info("Found verse", logging.Action("search"), logging.Translation(translation), logging.Reference(book, chapter, verse))
 }
 
 // navigateToNextVerse navigates to the next verse
@@ -279,8 +512,25 @@ func (c *ControllerWindow) navigateToPreviousVerse() {
 	}
 }
 
+// navigateBack shows the previously shown slide from the history
+func (c *ControllerWindow) navigateBack() {
+	if err := c.versePresentation.Back(); err != nil {
+		dialog.ShowInformation("History", "Nothing to go back to", c.window)
+	}
+}
+
+// navigateForward shows the next slide from the history
+func (c *ControllerWindow) navigateForward() {
+	if err := c.versePresentation.Forward(); err != nil {
+		dialog.ShowInformation("History", "Nothing to go forward to", c.window)
+	}
+}
+
 // switchTranslation switches to a different translation
 func (c *ControllerWindow) switchTranslation(translation string) {
+	slog.Info("Switched translation", logging.Action("switch translation"), logging.Translation(translation))
+	c.browsePanel.setTranslation(translation)
+
 	if c.versePresentation.GetVerse() == nil {
 		// No verse selected yet, nothing to do
 		return
@@ -293,20 +543,84 @@ func (c *ControllerWindow) switchTranslation(translation string) {
 	}
 }
 
-// updateLiveWindow updates the live window with the current verse
+// updateLiveWindow updates the live window with the current slide
 func (c *ControllerWindow) updateLiveWindow() {
 	if !c.liveWindow.IsOpen() {
 		dialog.ShowInformation("Error", "Live window is not open", c.window)
 		return
 	}
 
-	verse := c.versePresentation.GetVerse()
-	if verse == nil {
+	slide := c.versePresentation.GetSlide()
+	if slide == nil {
 		dialog.ShowInformation("Error", "No verse selected", c.window)
 		return
 	}
 
-	c.liveWindow.UpdateVerse(verse)
+	c.liveWindow.UpdateSlide(slide)
+}
+
+// startCountdown starts a countdown of the given number of minutes on the stage displays.
+// It replaces the timer of the Timer tab, which shows the same countdown.
+func (c *ControllerWindow) startCountdown(minutesText string) {
+	minutes, err := strconv.ParseFloat(minutesText, 64)
+	if err != nil || minutes <= 0 {
+		dialog.ShowInformation("Error", "Please enter the countdown length in minutes", c.window)
+		return
+	}
+
+	err = c.timer.Start(presentation.TimerSettings{
+		Mode:     presentation.TimerModeCountdown,
+		Duration: time.Duration(minutes * float64(time.Minute)),
+		Display:  presentation.TimerDisplayStage,
+	})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to start countdown: %w", err), c.window)
+	}
+}
+
+// timerExpired runs the timer's expiry action, e.g. showing the logo slide
+func (c *ControllerWindow) timerExpired(settings presentation.TimerSettings) {
+	if settings.OnExpiry != presentation.TimerExpireSlide {
+		return
+	}
+
+	slide, err := library.GetSlide(settings.ExpirySlideID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to show slide after timer: %w", err), c.window)
+		return
+	}
+	c.versePresentation.SetSlide(slide)
+}
+
+// logLive records a slide taken live in the service log,
+// or ends the current entry when slide is nil
+func (c *ControllerWindow) logLive(slide *presentation.Slide) {
+	var err error
+	if slide == nil {
+		err = c.serviceLog.Stop()
+	} else {
+		err = c.serviceLog.Show(slide)
+	}
+	if err != nil {
+		slog.Warn("Failed to write service log", "error", err)
+	}
+}
+
+// toggleLive opens the live window, or closes it when it is open
+func (c *ControllerWindow) toggleLive() {
+	if c.liveWindow.IsOpen() {
+		c.liveWindow.Close()
+	} else {
+		c.goLive()
+	}
+}
+
+// goLive opens the live window
+func (c *ControllerWindow) goLive() {
+	slog.Info("Opened live window", logging.Action("go live"))
+	c.liveWindow.Open()
+	c.updateLiveWindowStatus(true)
+	c.logLive(c.versePresentation.GetSlide())
 }
 
 // updateLiveWindowStatus updates the status label based on the live window state
@@ -320,12 +634,18 @@ func (c *ControllerWindow) updateLiveWindowStatus(isOpen bool) {
 }
 
 // updateCurrentVerseLabel updates the current verse label
-func (c *ControllerWindow) updateCurrentVerseLabel(verse *bible.Verse) {
-	if verse == nil {
+func (c *ControllerWindow) updateCurrentVerseLabel(slide *presentation.Slide) {
+	if slide == nil {
 		c.currentVerseLabel.SetText("No verse selected")
 		return
 	}
 
+	if !slide.IsVerse() {
+		c.currentVerseLabel.SetText(fmt.Sprintf("Slide: %s", slide.Title))
+		return
+	}
+
+	verse := slide.Verse
 	c.currentVerseLabel.SetText(
 		fmt.Sprintf(
 			"%s %d:%d (%s)",
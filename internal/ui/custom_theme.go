@@ -6,11 +6,20 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
 )
 
+// colorNameSlideText is the color of text on the current slide
+const colorNameSlideText fyne.ThemeColorName = "slideText"
+
+// colorNameWordsOfChrist is the color of red-letter text on the current slide
+const colorNameWordsOfChrist fyne.ThemeColorName = "wordsOfChrist"
+
 // presentationTheme customizes the appearance of the presentation window
 type presentationTheme struct {
-	windowSize fyne.Size
+	windowSize    fyne.Size
+	slideText     color.Color
+	wordsOfChrist color.Color
 }
 
 var _ fyne.Theme = (*presentationTheme)(nil)
@@ -23,13 +32,17 @@ func NewPresentationTheme() fyne.Theme {
 			1200,
 		), // Default size - standard 16:10 resolution
 		// windowSize: fyne.NewSize(1920, 1080), // Default size - standard 16:9 resolution
+		slideText:     color.White,
+		wordsOfChrist: presentation.GetTheme(presentation.DefaultTheme).WordsOfChrist,
 	}
 }
 
 // NewPresentationThemeWithSize creates a new theme instance with the specified window size
 func NewPresentationThemeWithSize(size fyne.Size) fyne.Theme {
 	return &presentationTheme{
-		windowSize: size,
+		windowSize:    size,
+		slideText:     color.White,
+		wordsOfChrist: presentation.GetTheme(presentation.DefaultTheme).WordsOfChrist,
 	}
 }
 
@@ -41,6 +54,12 @@ func (t *presentationTheme) Color(
 	if name == theme.ColorNameForeground {
 		return color.White // Force white text
 	}
+	if name == colorNameSlideText {
+		return t.slideText
+	}
+	if name == colorNameWordsOfChrist {
+		return t.wordsOfChrist
+	}
 	return theme.DefaultTheme().Color(name, variant)
 }
 
@@ -84,3 +103,57 @@ func (t *presentationTheme) Size(name fyne.ThemeSizeName) float32 {
 func (t *presentationTheme) UpdateWindowSize(size fyne.Size) {
 	t.windowSize = size
 }
+
+// UpdateSlideText sets the color used for text on the current slide
+func (t *presentationTheme) UpdateSlideText(c color.Color) {
+	t.slideText = c
+}
+
+// UpdateWordsOfChrist sets the color used for red-letter text on the current slide
+func (t *presentationTheme) UpdateWordsOfChrist(c color.Color) {
+	t.wordsOfChrist = c
+}
+
+// stageTheme customizes the appearance of the stage (confidence monitor) display
+type stageTheme struct{}
+
+var _ fyne.Theme = (*stageTheme)(nil)
+
+// Color returns white text, yellow for the primary accent and defaults otherwise
+func (t *stageTheme) Color(
+	name fyne.ThemeColorName,
+	variant fyne.ThemeVariant,
+) color.Color {
+	switch name {
+	case theme.ColorNameForeground:
+		return color.White
+	case theme.ColorNamePrimary:
+		return color.NRGBA{R: 0xff, G: 0xd5, B: 0x4f, A: 0xff} // Amber for clock and countdown
+	case theme.ColorNamePlaceHolder:
+		return color.NRGBA{R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff} // Grey for the next verse
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+// Font delegates to the default theme
+func (t *stageTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+// Icon delegates to the default theme
+func (t *stageTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+// Size returns fixed, readable-at-a-distance text sizes for the stage display
+func (t *stageTheme) Size(name fyne.ThemeSizeName) float32 {
+	switch name {
+	case theme.SizeNameHeadingText:
+		return 56
+	case theme.SizeNameSubHeadingText:
+		return 36
+	case theme.SizeNameText:
+		return 28
+	}
+	return theme.DefaultTheme().Size(name)
+}
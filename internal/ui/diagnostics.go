@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// diagnosticsLogLines is the number of recent log lines included in the diagnostics
+const diagnosticsLogLines = 200
+
+// copyDiagnostics copies details about the installation, the translations and
+// the recent log to the clipboard, to paste into a support request
+func (c *ControllerWindow) copyDiagnostics() {
+	c.window.Clipboard().SetContent(c.diagnostics())
+	slog.Info("Copied diagnostics", logging.Action("copy diagnostics"))
+	dialog.ShowInformation("Diagnostics Copied", "Diagnostics were copied to the clipboard. Paste them into your support request.", c.window)
+}
+
+// diagnostics describes the installation, the translations and the recent log
+func (c *ControllerWindow) diagnostics() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Mr Verse diagnostics, %s\n\n", time.Now().Format(time.RFC3339))
+
+	fmt.Fprintf(&b, "Version: %s\n", buildVersion())
+	fmt.Fprintf(&b, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Configuration file: %s\n", appdir.ConfigFile())
+	if err := config.LoadError(); err != nil {
+		fmt.Fprintf(&b, "Configuration error: %v\n", err)
+	}
+	fmt.Fprintf(&b, "Home: %s\n", appdir.Home())
+	fmt.Fprintf(&b, "Database: %s%s\n", appdir.DBPath(), fileSize(appdir.DBPath()))
+	fmt.Fprintf(&b, "Translations: %s\n", appdir.TranslationsDir())
+	fmt.Fprintf(&b, "Media: %s\n", appdir.MediaDir())
+	fmt.Fprintf(&b, "Log file: %s\n", logging.File())
+	fmt.Fprintf(&b, "Live window open: %t\n", c.liveWindow.IsOpen())
+	if addr := config.HTTPAddr(); addr != "" {
+		fmt.Fprintf(&b, "HTTP server: %s\n", addr)
+	}
+
+	b.WriteString("\nTranslations:\n")
+	translations, err := bible.GetTranslations()
+	if err != nil {
+		fmt.Fprintf(&b, "  failed to list: %v\n", err)
+	}
+	for _, info := range translations {
+		fmt.Fprintf(&b, "  %s (%s)", info.Name, info.Language)
+		if info.Default {
+			b.WriteString(" default")
+		}
+		if !info.Visible {
+			b.WriteString(" hidden")
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\nRecent log:\n")
+	lines, err := logging.Tail(diagnosticsLogLines)
+	if err != nil {
+		fmt.Fprintf(&b, "failed to read: %v\n", err)
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// buildVersion returns the version of the module and the commit it was built from, if known
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			version += " " + setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				version += " (modified)"
+			}
+		}
+	}
+	return version
+}
+
+// fileSize returns the size of a file for display, e.g. " (4.2 MB)", or "" if it can't be read
+func fileSize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(" (%.1f MB)", float64(info.Size())/(1<<20))
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/software"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// headlessApp sets up an app without windows for exporting from the command line
+var headlessApp sync.Once
+
+// ExportImage draws a slide the way the live window shows it and saves it to a
+// PNG or JPEG file, chosen by the file extension. It doesn't need the application
+// window, e.g. when run from the command line.
+func ExportImage(path string, slide *presentation.Slide, width, height int, showSection bool) error {
+	headlessApp.Do(func() {
+		test.NewApp()
+	})
+
+	img, err := renderSlide(slide, width, height, slideBackground(nil, slide), showSection)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := encodeImage(f, filepath.Ext(path), img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// renderSlide draws a slide offscreen with the live window's layout and theme
+func renderSlide(slide *presentation.Slide, width, height int, background *media.Background, showSection bool) (image.Image, error) {
+	size := fyne.NewSize(float32(width), float32(height))
+	st := presentation.GetTheme(slide.Theme)
+	th := &presentationTheme{
+		windowSize:    size,
+		slideText:     st.Foreground,
+		wordsOfChrist: st.WordsOfChrist,
+	}
+
+	view := newSlideView()
+	view.setSlide(slide, showSection)
+	if background != nil {
+		m, err := media.Load(background.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load background %s: %w", background.File, err)
+		}
+		view.drawBackground(m.Frame(), background, size)
+	}
+
+	c := software.NewCanvas()
+	c.SetPadded(false)
+	c.SetContent(container.NewThemeOverride(container.NewStack(view.layers()...), th))
+	c.Resize(size)
+
+	// Wrapped text only knows its height once it has been laid out at its width
+	c.Content().Refresh()
+	return c.Capture(), nil
+}
+
+// encodeImage writes an image as PNG or JPEG for a file extension such as ".png"
+func encodeImage(w io.Writer, ext string, img image.Image) error {
+	switch strings.ToLower(ext) {
+	case ".png":
+		return png.Encode(w, img)
+	case ".jpg", ".jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 92})
+	default:
+		return fmt.Errorf("unsupported image format %q, use .png or .jpg", ext)
+	}
+}
+
+// showExportImageDialog asks for a passage, theme and size and saves it as an image
+func (c *ControllerWindow) showExportImageDialog() {
+	current := c.versePresentation.GetSlide()
+
+	referenceEntry := widget.NewEntry()
+	referenceEntry.SetPlaceHolder("Current slide, or e.g., John 3:16-18")
+	themeSelect := widget.NewSelect(presentation.ThemeNames(), nil)
+	themeSelect.SetSelected(presentation.DefaultTheme)
+	if current != nil && current.Theme != "" {
+		themeSelect.SetSelected(current.Theme)
+	}
+	sizeEntry := widget.NewSelectEntry(presentation.ImageSizes)
+	sizeEntry.SetText(presentation.ImageSizes[0])
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Passage", referenceEntry),
+		widget.NewFormItem("Theme", themeSelect),
+		widget.NewFormItem("Size", sizeEntry),
+	}
+
+	dialog.ShowForm("Export Image", "Export", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		width, height, err := presentation.ParseImageSize(sizeEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+
+		slide := current
+		if reference := strings.TrimSpace(referenceEntry.Text); reference != "" {
+			slide, err = c.passageSlide(reference)
+			if err != nil {
+				dialog.ShowError(err, c.window)
+				return
+			}
+		}
+		if slide == nil {
+			dialog.ShowInformation("Error", "Please show a slide or enter a passage to export", c.window)
+			return
+		}
+
+		// Export with the chosen theme without changing the slide being shown
+		exported := *slide
+		exported.Theme = themeSelect.Selected
+		c.saveSlideImage(&exported, width, height)
+	}, c.window)
+}
+
+// passageSlide creates a slide for a passage in the selected translation
+func (c *ControllerWindow) passageSlide(reference string) (*presentation.Slide, error) {
+	book, chapter, first, last, err := bible.ParsePassageIn(reference, c.referenceLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("invalid passage: %w", err)
+	}
+	verses, err := bible.GetPassage(c.translationSelect.Selected, book, chapter, first, last)
+	if err != nil {
+		return nil, err
+	}
+	return presentation.NewPassageSlide(verses), nil
+}
+
+// saveSlideImage asks where to save the image of a slide and writes it
+func (c *ControllerWindow) saveSlideImage(slide *presentation.Slide, width, height int) {
+	preferences := c.app.Preferences()
+	img, err := renderSlide(
+		slide,
+		width,
+		height,
+		slideBackground(preferences, slide),
+		preferences.Bool(config.PrefKeyShowSectionHeadings),
+	)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to draw image: %w", err), c.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if err := encodeImage(writer, writer.URI().Extension(), img); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export image: %w", err), c.window)
+			return
+		}
+
+		slog.Info("Exported image", logging.Action("export image"), "file", writer.URI().Path(), "width", width, "height", height)
+		dialog.ShowInformation(
+			"Image Exported",
+			fmt.Sprintf("Exported %dx%d image to %s", width, height, writer.URI().Name()),
+			c.window,
+		)
+	}, c.window)
+
+	saveDialog.SetFileName(imageFileName(slide.Title) + ".png")
+	saveDialog.Show()
+}
+
+// imageFileName makes a file name from a slide title, e.g. "John-3-16-NLT"
+func imageFileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == ' ' || r == ':' || r == '/' || r == '\\' {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		return "slide"
+	}
+	return name
+}
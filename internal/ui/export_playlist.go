@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/appdir"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/playlist"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// showExportPlaylistDialog asks for a list of references, a theme and a format
+// and saves the passages as a slide deck or a PDF handout
+func (c *ControllerWindow) showExportPlaylistDialog() {
+	preferences := c.app.Preferences()
+	current := c.versePresentation.GetSlide()
+
+	referencesEntry := widget.NewMultiLineEntry()
+	referencesEntry.SetPlaceHolder("One reference per line, e.g.\nJohn 3:16-18\nPsalm 23:1-6")
+	referencesEntry.SetMinRowsVisible(8)
+	referencesEntry.SetText(preferences.String(config.PrefKeyPlaylist))
+	themeSelect := widget.NewSelect(presentation.ThemeNames(), nil)
+	themeSelect.SetSelected(presentation.DefaultTheme)
+	if current != nil && current.Theme != "" {
+		themeSelect.SetSelected(current.Theme)
+	}
+	formatSelect := widget.NewSelect(playlist.Formats, nil)
+	formatSelect.SetSelected(playlist.FormatPPTX)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("References", referencesEntry),
+		widget.NewFormItem("Theme", themeSelect),
+		widget.NewFormItem("Format", formatSelect),
+	}
+
+	form := dialog.NewForm("Export Playlist", "Export", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		preferences.SetString(config.PrefKeyPlaylist, referencesEntry.Text)
+
+		passages, err := playlist.Load(c.translationSelect.Selected, playlist.ParseReferences(referencesEntry.Text))
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+
+		// Export before asking for a file so nothing is written when it fails
+		var data bytes.Buffer
+		if err := playlist.Export(&data, formatSelect.Selected, passages, presentation.GetTheme(themeSelect.Selected)); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export playlist: %w", err), c.window)
+			return
+		}
+		c.savePlaylist(data.Bytes(), formatSelect.Selected, len(passages))
+	}, c.window)
+	form.Resize(fyne.NewSize(500, 450))
+	form.Show()
+}
+
+// savePlaylist asks where to save an exported playlist and writes it
+func (c *ControllerWindow) savePlaylist(data []byte, format string, count int) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save playlist: %w", err), c.window)
+			return
+		}
+
+		slog.Info("Exported playlist", logging.Action("export playlist"), "file", writer.URI().Path(), "passages", count)
+		dialog.ShowInformation(
+			"Playlist Exported",
+			fmt.Sprintf("Exported %d passages to %s", count, writer.URI().Name()),
+			c.window,
+		)
+	}, c.window)
+
+	saveDialog.SetFileName("playlist." + format)
+
+	// Start in the playlists folder
+	if dir := appdir.PlaylistsDir(); os.MkdirAll(dir, 0755) == nil {
+		if lister, err := storage.ListerForURI(storage.NewFileURI(dir)); err == nil {
+			saveDialog.SetLocation(lister)
+		}
+	}
+	saveDialog.Show()
+}
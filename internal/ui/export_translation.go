@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// translationExtensions are the file extensions of translation export formats
+var translationExtensions = map[string]string{
+	bible.ExportFormatJSON: ".json",
+	bible.ExportFormatCSV:  ".csv",
+	bible.ExportFormatOSIS: ".osis.xml",
+}
+
+// showExportTranslationDialog asks for a translation, books and a format
+// and saves them as JSON, CSV or OSIS XML
+func (c *ControllerWindow) showExportTranslationDialog() {
+	translationSelect := widget.NewSelect(c.translationSelect.Options, nil)
+	translationSelect.SetSelected(c.translationSelect.Selected)
+	booksEntry := widget.NewEntry()
+	booksEntry.SetPlaceHolder("All, or e.g. Matthew-John")
+	formatSelect := widget.NewSelect(bible.ExportFormats, nil)
+	formatSelect.SetSelected(bible.ExportFormatJSON)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Translation", translationSelect),
+		widget.NewFormItem("Books", booksEntry),
+		widget.NewFormItem("Format", formatSelect),
+	}
+
+	dialog.ShowForm("Export Translation", "Export", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		translation := translationSelect.Selected
+		books, err := bible.ParseBookRange(translation, booksEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+
+		// Export before asking for a file so nothing is written when it fails
+		var data bytes.Buffer
+		if err := bible.ExportTranslation(&data, formatSelect.Selected, translation, books); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export translation: %w", err), c.window)
+			return
+		}
+		c.saveTranslation(data.Bytes(), translation+translationExtensions[formatSelect.Selected], len(books))
+	}, c.window)
+}
+
+// saveTranslation asks where to save an exported translation and writes it
+func (c *ControllerWindow) saveTranslation(data []byte, fileName string, books int) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save translation: %w", err), c.window)
+			return
+		}
+
+		slog.Info("Exported translation", logging.Action("export translation"), "file", writer.URI().Path(), "books", books)
+		dialog.ShowInformation(
+			"Translation Exported",
+			fmt.Sprintf("Exported %d books to %s", books, writer.URI().Name()),
+			c.window,
+		)
+	}, c.window)
+
+	saveDialog.SetFileName(fileName)
+	saveDialog.Show()
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// followControls builds the follow-along controls for the Bible tab
+func (c *ControllerWindow) followControls() fyne.CanvasObject {
+	secondsEntry := widget.NewEntry()
+	secondsEntry.SetPlaceHolder("Seconds per verse")
+	secondsEntry.SetText("10")
+
+	wordsEntry := widget.NewEntry()
+	wordsEntry.SetPlaceHolder("Words per minute (optional)")
+
+	endEntry := widget.NewEntry()
+	endEntry.SetPlaceHolder("Stop after (e.g., John 3:21 or 21)")
+
+	startButton := widget.NewButton("Start Follow Along", func() {
+		c.startFollowAlong(secondsEntry.Text, wordsEntry.Text, endEntry.Text)
+	})
+	pauseButton := widget.NewButton("Pause", func() {
+		if c.autoAdvance.Status().State == presentation.AutoAdvancePaused {
+			c.autoAdvance.Resume()
+		} else {
+			c.autoAdvance.Pause()
+		}
+	})
+	stopButton := widget.NewButton("Stop", func() {
+		c.autoAdvance.Stop()
+	})
+
+	c.followStatus = widget.NewLabel("Stopped")
+	c.autoAdvance.SetOnChange(func(status presentation.AutoAdvanceStatus) {
+		if status.State == presentation.AutoAdvancePaused {
+			pauseButton.SetText("Resume")
+		} else {
+			pauseButton.SetText("Pause")
+		}
+		c.followStatus.SetText(strings.ToUpper(status.State[:1]) + status.State[1:])
+	})
+
+	return container.NewVBox(
+		container.NewGridWithColumns(3, secondsEntry, wordsEntry, endEntry),
+		container.NewGridWithColumns(3, startButton, pauseButton, stopButton),
+		container.NewHBox(widget.NewLabel("Status:"), c.followStatus),
+	)
+}
+
+// startFollowAlong starts advancing from the current verse. The end of the
+// passage is a reference, a verse number in the current chapter, or empty.
+func (c *ControllerWindow) startFollowAlong(secondsText, wordsText, endText string) {
+	var settings presentation.AutoAdvanceSettings
+
+	if wordsText = strings.TrimSpace(wordsText); wordsText != "" {
+		words, err := strconv.Atoi(wordsText)
+		if err != nil || words <= 0 {
+			dialog.ShowError(fmt.Errorf("invalid words per minute: %q", wordsText), c.window)
+			return
+		}
+		settings.WordsPerMinute = words
+	} else {
+		seconds, err := strconv.ParseFloat(strings.TrimSpace(secondsText), 64)
+		if err != nil || seconds <= 0 {
+			dialog.ShowError(fmt.Errorf("invalid seconds per verse: %q", secondsText), c.window)
+			return
+		}
+		settings.Interval = time.Duration(seconds * float64(time.Second))
+	}
+
+	if endText = strings.TrimSpace(endText); endText != "" {
+		current := c.versePresentation.GetVerse()
+		if verse, err := strconv.Atoi(endText); err == nil && current != nil {
+			settings.EndBook, settings.EndChapter, settings.EndVerse = current.Book, current.Chapter, verse
+		} else {
+			book, chapter, verse, err := bible.ParseBibleReferenceIn(endText, c.referenceLanguage())
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("invalid passage end: %w", err), c.window)
+				return
+			}
+			settings.EndBook, settings.EndChapter, settings.EndVerse = book, chapter, verse
+		}
+	}
+
+	if err := c.autoAdvance.Start(settings); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to start follow along: %w", err), c.window)
+	}
+}
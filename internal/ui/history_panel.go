@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// historyPanel lists recently shown slides so they can be shown again with one click
+type historyPanel struct {
+	controller *ControllerWindow
+	entries    []presentation.HistoryEntry
+	list       *widget.List
+}
+
+// newHistoryPanel creates the history panel for the controller window
+func newHistoryPanel(c *ControllerWindow) *historyPanel {
+	return &historyPanel{
+		controller: c,
+	}
+}
+
+// content builds the history panel UI
+func (p *historyPanel) content() fyne.CanvasObject {
+	p.list = widget.NewList(
+		func() int {
+			return len(p.entries)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("00:00:00  John 3:16 NLT")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			entry := p.entries[id]
+			item.(*widget.Label).SetText(
+				fmt.Sprintf("%s  %s", entry.ShownAt.Format("15:04:05"), entry.Slide.Title),
+			)
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		slide := p.entries[id].Slide
+		p.list.UnselectAll()
+		p.controller.versePresentation.SetSlide(slide)
+	}
+
+	backButton := widget.NewButton("Back", func() {
+		p.controller.navigateBack()
+	})
+	forwardButton := widget.NewButton("Forward", func() {
+		p.controller.navigateForward()
+	})
+
+	exportButton := widget.NewButton("Export Service Log", func() {
+		p.controller.showServiceLogExportDialog()
+	})
+
+	return container.NewBorder(
+		container.NewVBox(
+			container.NewGridWithColumns(2, backButton, forwardButton),
+			widget.NewLabel("Recently Shown:"),
+		),
+		exportButton,
+		nil,
+		nil,
+		p.list,
+	)
+}
+
+// refresh reloads the history list
+func (p *historyPanel) refresh() {
+	p.entries = p.controller.versePresentation.History.Entries()
+	p.list.Refresh()
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// importProgress shows the progress of translations being imported in the status bar
+type importProgress struct {
+	label     *widget.Label
+	bar       *widget.ProgressBar
+	container *fyne.Container
+}
+
+// newImportProgress creates the import progress, hidden until an import starts
+func newImportProgress() *importProgress {
+	p := &importProgress{
+		label: widget.NewLabel(""),
+		bar:   widget.NewProgressBar(),
+	}
+	p.container = container.NewHBox(
+		p.label,
+		container.NewGridWrap(fyne.NewSize(200, p.bar.MinSize().Height), p.bar),
+	)
+	p.container.Hide()
+	return p
+}
+
+// content returns the import progress widgets
+func (p *importProgress) content() fyne.CanvasObject {
+	return p.container
+}
+
+// update shows a seeding progress event
+func (p *importProgress) update(progress bible.SeedProgress) {
+	if progress.Done {
+		p.container.Hide()
+		return
+	}
+
+	// Each file is an equal share of the bar, filled book by book
+	done := float64(progress.FileIndex - 1)
+	if progress.BookCount > 0 {
+		done += float64(progress.BookIndex) / float64(progress.BookCount)
+	}
+	p.bar.SetValue(done / float64(progress.FileCount))
+
+	text := fmt.Sprintf("Importing %s (%d of %d)", progress.Translation, progress.FileIndex, progress.FileCount)
+	if progress.Book != "" {
+		text += fmt.Sprintf(": %s, %d verses", progress.Book, progress.Rows)
+	}
+	p.label.SetText(text)
+	p.container.Show()
+}
+
+// seedTranslations imports new translations from the translations directory with
+// their chapter headers, showing the progress, then makes them available
+// and checks their files for problems
+func (c *ControllerWindow) seedTranslations() {
+	seeded, err := bible.SeedBibleData(c.importProgress.update)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to seed Bible data: %w", err), c.window)
+		slog.Error("Failed to seed Bible data", logging.Action("seed"), "error", err)
+		// Not a fatal error, can continue
+	}
+
+	// Seed chapter headers (runs safely even if verses already exist)
+	if err := bible.SeedChapterHeaders(); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to seed chapter headers: %w", err), c.window)
+		slog.Error("Failed to seed chapter headers", logging.Action("seed"), "error", err)
+		// Not a fatal error, can continue
+	}
+
+	if len(seeded) > 0 {
+		c.translationsChanged()
+		c.verifyImported(seeded)
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// maxPreviewIssues is the most issues of a file listed before it is imported
+const maxPreviewIssues = 5
+
+// showImportTranslationDialog asks for a Bible JSON file to import as a new translation
+func (c *ControllerWindow) showImportTranslationDialog() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		reader.Close()
+
+		c.importTranslations([]fyne.URI{reader.URI()})
+	}, c.window)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	fileDialog.Show()
+}
+
+// dropFiles imports Bible JSON files dropped onto the controller window
+func (c *ControllerWindow) dropFiles(_ fyne.Position, uris []fyne.URI) {
+	c.importTranslations(uris)
+}
+
+// importTranslations previews translation files one after another
+// and imports the ones the operator confirms
+func (c *ControllerWindow) importTranslations(uris []fyne.URI) {
+	if len(uris) == 0 {
+		return
+	}
+	uri := uris[0]
+	next := func() {
+		c.importTranslations(uris[1:])
+	}
+
+	if uri.Scheme() != "file" {
+		c.showImportError(fmt.Errorf("%s is not a local file", uri.Name()), next)
+		return
+	}
+	preview, err := bible.PreviewImport(uri.Path())
+	if err != nil {
+		c.showImportError(err, next)
+		return
+	}
+
+	confirm := dialog.NewConfirm("Import Translation", previewText(preview), func(ok bool) {
+		if !ok {
+			next()
+			return
+		}
+
+		// Import in the background with the progress in the status bar
+		go func() {
+			translation, err := bible.ImportTranslation(preview.Path, c.importProgress.update)
+			if err != nil {
+				slog.Error("Failed to import translation", logging.Action("import"), logging.Translation(preview.Translation), "error", err)
+				c.showImportError(fmt.Errorf("failed to import %s: %w", preview.Translation, err), next)
+				return
+			}
+			c.translationsChanged()
+
+			info := dialog.NewInformation(
+				"Translation Imported",
+				fmt.Sprintf("%s is ready to use", translation),
+				c.window,
+			)
+			info.SetOnClosed(next)
+			info.Show()
+		}()
+	}, c.window)
+	confirm.SetConfirmText("Import")
+	confirm.Show()
+}
+
+// showImportError shows an import error and then continues with next
+func (c *ControllerWindow) showImportError(err error, next func()) {
+	d := dialog.NewError(err, c.window)
+	d.SetOnClosed(next)
+	d.Show()
+}
+
+// previewText describes a translation file before it is imported
+func previewText(preview *bible.ImportPreview) string {
+	report := preview.Report
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Translation: %s\n", preview.Translation)
+	if preview.Version != "" {
+		fmt.Fprintf(&b, "Version: %s\n", preview.Version)
+	}
+	fmt.Fprintf(&b, "Language: %s\n", preview.Language)
+	fmt.Fprintf(&b, "%d books, %d chapters, %d verses\n", report.Books, report.Chapters, report.Verses)
+
+	if len(report.Issues) == 0 {
+		b.WriteString("No problems found.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d errors, %d warnings:\n",
+		report.Count(bible.SeverityError), report.Count(bible.SeverityWarning))
+	for i, issue := range report.Issues {
+		if i == maxPreviewIssues {
+			fmt.Fprintf(&b, "... and %d more\n", len(report.Issues)-maxPreviewIssues)
+			break
+		}
+		b.WriteString(issue.String() + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"log/slog"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// keymap runs controller actions for the keys bound to them in the configuration file.
+// Keys with modifiers are window shortcuts; plain keys work when no entry has the focus,
+// or in the search box when they don't edit text.
+type keymap struct {
+	controller *ControllerWindow
+	mutex      sync.Mutex
+	actions    map[config.Key]string
+	shortcuts  []fyne.Shortcut
+}
+
+// newKeymap creates the keymap of the controller window
+func newKeymap(c *ControllerWindow) *keymap {
+	k := &keymap{controller: c}
+	c.window.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+		k.run(config.Key{Name: event.Name})
+	})
+	return k
+}
+
+// apply binds the keys of a keymap, replacing the keys bound before.
+// Invalid bindings were reported when the configuration file was loaded.
+func (k *keymap) apply(bindings map[string]string) {
+	actions, _ := config.ParseKeymap(bindings)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	canvas := k.controller.window.Canvas()
+	for _, shortcut := range k.shortcuts {
+		canvas.RemoveShortcut(shortcut)
+	}
+	k.shortcuts = nil
+
+	for key := range actions {
+		if key.Modifier == 0 {
+			continue // Plain keys arrive through the typed key handlers
+		}
+		key := key
+		shortcut := &desktop.CustomShortcut{KeyName: key.Name, Modifier: key.Modifier}
+		canvas.AddShortcut(shortcut, func(fyne.Shortcut) {
+			k.run(key)
+		})
+		k.shortcuts = append(k.shortcuts, shortcut)
+	}
+	k.actions = actions
+}
+
+// run runs the action bound to a key, and reports whether there is one
+func (k *keymap) run(key config.Key) bool {
+	k.mutex.Lock()
+	action, ok := k.actions[key]
+	k.mutex.Unlock()
+	if !ok {
+		return false
+	}
+
+	slog.Debug("Key pressed", "key", key.String(), logging.Action(action))
+	c := k.controller
+	switch action {
+	case config.ActionNextVerse:
+		c.navigateToNextVerse()
+	case config.ActionPreviousVerse:
+		c.navigateToPreviousVerse()
+	case config.ActionBack:
+		c.navigateBack()
+	case config.ActionForward:
+		c.navigateForward()
+	case config.ActionToggleLive:
+		c.toggleLive()
+	case config.ActionUpdateLive:
+		c.updateLiveWindow()
+	case config.ActionFocusSearch:
+		c.window.Canvas().Focus(c.searchEntry)
+	}
+	return true
+}
+
+// isEditingKey reports whether a plain key edits or moves through text in an entry,
+// so it is left to the entry instead of running the action bound to it
+func isEditingKey(name fyne.KeyName) bool {
+	switch name {
+	case fyne.KeyF1, fyne.KeyF2, fyne.KeyF3, fyne.KeyF4, fyne.KeyF5, fyne.KeyF6,
+		fyne.KeyF7, fyne.KeyF8, fyne.KeyF9, fyne.KeyF10, fyne.KeyF11, fyne.KeyF12,
+		fyne.KeyPageUp, fyne.KeyPageDown, fyne.KeyEscape:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"image"
+	"log/slog"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// slideBackground returns the media background for a slide: its own background,
+// else its theme's background, else nil for the theme color.
+// Theme backgrounds are skipped without preferences.
+func slideBackground(preferences fyne.Preferences, slide *presentation.Slide) *media.Background {
+	if slide.Background != "" {
+		mode := slide.BackgroundMode
+		if mode == "" {
+			mode = media.ModeFill
+		}
+		return &media.Background{
+			File: slide.Background,
+			Mode: mode,
+			Dim:  slide.BackgroundDim,
+		}
+	}
+
+	if preferences == nil {
+		return nil
+	}
+
+	themeName := slide.Theme
+	if themeName == "" {
+		themeName = presentation.DefaultTheme
+	}
+	return config.GetThemeBackground(preferences, themeName)
+}
+
+// setBackground shows a media background behind the slide, or only the theme color when nil
+func (lw *LiveWindow) setBackground(background *media.Background) {
+	lw.bgMutex.Lock()
+	defer lw.bgMutex.Unlock()
+
+	if sameBackground(lw.background, background) {
+		return
+	}
+
+	lw.stopBackgroundAnimation()
+	lw.background = nil
+	lw.bgFrame = nil
+	lw.hideBackground()
+	if background == nil {
+		lw.bgMedia, lw.bgFile = nil, ""
+		return
+	}
+
+	// Keep only the current file decoded, so backgrounds that were shown
+	// before don't hold on to memory
+	if lw.bgMedia == nil || lw.bgFile != background.File {
+		lw.bgMedia, lw.bgFile = nil, ""
+		m, err := media.Load(background.File)
+		if err != nil {
+			slog.Warn("Failed to load background", "file", background.File, "error", err)
+			return
+		}
+		lw.bgMedia, lw.bgFile = m, background.File
+	}
+
+	lw.background = background
+	if !lw.bgMedia.IsMotion() {
+		lw.bgFrame = lw.bgMedia.Frame()
+		lw.showBackgroundFrameLocked()
+		return
+	}
+
+	player := lw.bgMedia.Play()
+	frame, delay := player.Next()
+	lw.bgFrame = frame
+	lw.showBackgroundFrameLocked()
+
+	lw.bgStop = make(chan struct{})
+	go lw.animateBackground(player, background, delay, lw.bgStop)
+}
+
+// showBackgroundFrame draws the current background frame for the window size
+func (lw *LiveWindow) showBackgroundFrame() {
+	lw.bgMutex.Lock()
+	defer lw.bgMutex.Unlock()
+	lw.showBackgroundFrameLocked()
+}
+
+// showBackgroundFrameLocked draws the current background frame; bgMutex is held
+func (lw *LiveWindow) showBackgroundFrameLocked() {
+	if lw.bgFrame == nil || lw.background == nil {
+		return
+	}
+
+	lw.drawBackground(lw.bgFrame, lw.background, lw.window.Canvas().Size())
+}
+
+// animateBackground plays a motion background in a loop until stop is closed.
+// It only draws frames of its own player, and checks stop under the lock,
+// so a background chosen meanwhile is never drawn with its frames.
+func (lw *LiveWindow) animateBackground(player *media.Player, background *media.Background, delay time.Duration, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+
+		var frame image.Image
+		frame, delay = player.Next()
+
+		lw.bgMutex.Lock()
+		select {
+		case <-stop:
+			lw.bgMutex.Unlock()
+			return
+		default:
+		}
+		lw.bgFrame = frame
+		lw.drawBackground(frame, background, lw.window.Canvas().Size())
+		lw.bgMutex.Unlock()
+	}
+}
+
+// stopBackgroundAnimation stops a playing motion background; bgMutex is held
+func (lw *LiveWindow) stopBackgroundAnimation() {
+	if lw.bgStop != nil {
+		close(lw.bgStop)
+		lw.bgStop = nil
+	}
+}
+
+// closeBackground stops a playing motion background when the window closes
+func (lw *LiveWindow) closeBackground() {
+	lw.bgMutex.Lock()
+	defer lw.bgMutex.Unlock()
+	lw.stopBackgroundAnimation()
+}
+
+// clearMediaCache forgets the decoded background so a changed file is loaded again
+func (lw *LiveWindow) clearMediaCache() {
+	lw.bgMutex.Lock()
+	defer lw.bgMutex.Unlock()
+	lw.bgFile = "" // Loaded again when the background changes
+}
+
+// sameBackground reports whether two backgrounds are the same
+func sameBackground(a, b *media.Background) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
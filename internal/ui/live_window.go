@@ -1,37 +1,64 @@
 package ui
 
 import (
-	"fmt"
+	"image"
 	"image/color"
-	"log"
+	"log/slog"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
-	"fyne.io/fyne/v2/theme"
-	"fyne.io/fyne/v2/widget"
-	"github.com/mr-ministry/mr-verse/internal/bible"
 	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
 )
 
 // LiveWindow represents the presentation window
 type LiveWindow struct {
-	window    fyne.Window
-	app       fyne.App
-	verseText *widget.RichText
-	reference *widget.RichText
-	isOpen    bool
-	onClose   func()
+	*slideView
+	window      fyne.Window
+	app         fyne.App
+	timer       *presentation.Timer
+	bgMutex     sync.Mutex        // Guards the background, which a motion background animates
+	background  *media.Background // Media background shown, nil for the theme color
+	bgMedia     *media.Media      // The decoded background file; only the current one is kept
+	bgFile      string            // Name of the file bgMedia was decoded from
+	bgFrame     image.Image       // Frame of the background being shown
+	bgStop      chan struct{}     // Closed to stop a motion background
+	timerScreen *fyne.Container   // Full-screen timer, hides the slide
+	timerLabel  *canvas.Text
+	timerText   *canvas.Text
+	timerCorner *fyne.Container // Timer in the top-right corner over the slide
+	cornerText  *canvas.Text
+	messages    *presentation.MessageQueue
+	messageID   int // ID of the message shown, 0 for none
+	tickerBand  *fyne.Container
+	tickerSpace *canvas.Rectangle
+	tickerText  *canvas.Text
+	tickerAnim  *fyne.Animation
+	lowerThird  *fyne.Container
+	lowerText   *canvas.Text
+	stop        chan struct{} // Closed when the window closes, to stop the overlays ticking
+	isOpen      bool
+	onClose     func()
 }
 
 // NewLiveWindow creates a new live window
-func NewLiveWindow(app fyne.App, onClose func()) *LiveWindow {
+func NewLiveWindow(
+	app fyne.App,
+	timer *presentation.Timer,
+	messages *presentation.MessageQueue,
+	onClose func(),
+) *LiveWindow {
 	return &LiveWindow{
-		app:     app,
-		onClose: onClose,
-		isOpen:  false,
+		app:      app,
+		timer:    timer,
+		messages: messages,
+		onClose:  onClose,
+		isOpen:   false,
 	}
 }
 
@@ -68,6 +95,9 @@ func (lw *LiveWindow) Open() {
 
 	lw.window.SetOnClosed(func() {
 		lw.isOpen = false
+		lw.stopTicking()
+		lw.stopTicker()
+		lw.closeBackground()
 		if lw.onClose != nil {
 			lw.onClose()
 		}
@@ -81,7 +111,7 @@ func (lw *LiveWindow) Open() {
 	lw.window.CenterOnScreen()
 
 	if bounds != nil {
-		log.Println("Bounds not found, defaulting to full screen")
+		slog.Debug("Showing live window full screen", "bounds", *bounds)
 		lw.window.SetFullScreen(true)
 	}
 
@@ -92,53 +122,65 @@ func (lw *LiveWindow) Open() {
 	lw.window.SetFullScreen(true)
 	lw.window.Show()
 	lw.isOpen = true
+
+	// Keep the timer and message overlays ticking
+	lw.stop = make(chan struct{})
+	go lw.tickOverlays(lw.stop)
 }
 
 // setupUI creates the UI components for the live window
 func (lw *LiveWindow) setupUI() {
-	lw.verseText = widget.NewRichText()
-	lw.verseText.Wrapping = fyne.TextWrapWord
-
-	// Make the text larger for presentation with white color
-	lw.verseText.Segments = []widget.RichTextSegment{
-		&widget.TextSegment{
-			Style: widget.RichTextStyle{
-				TextStyle: fyne.TextStyle{
-					Bold: true,
-				},
-				Alignment: fyne.TextAlignCenter,
-				SizeName:  theme.SizeNameHeadingText,
-			},
-			Text: "JESUS IS KING",
-		},
-	}
-
-	lw.reference = widget.NewRichText()
-	lw.reference.Segments = []widget.RichTextSegment{
-		&widget.TextSegment{
-			Style: widget.RichTextStyle{
-				TextStyle: fyne.TextStyle{
-					Bold: true,
-				},
-				SizeName:  theme.SizeNameSubHeadingText,
-				Alignment: fyne.TextAlignCenter,
-			},
-			Text: " ",
-		},
-	}
-
-	// Create the layout
-	content := container.NewVBox(
-		container.NewCenter(lw.reference),
-		widget.NewSeparator(),
-		layout.NewSpacer(),
-		container.New(layout.NewPaddedLayout(), lw.verseText),
-		layout.NewSpacer(),
+	lw.slideView = newSlideView()
+
+	// Create the full-screen timer
+	lw.timerLabel = canvas.NewText("", color.White)
+	lw.timerLabel.Alignment = fyne.TextAlignCenter
+	lw.timerText = canvas.NewText("", color.White)
+	lw.timerText.Alignment = fyne.TextAlignCenter
+	lw.timerText.TextStyle = fyne.TextStyle{Bold: true}
+	lw.timerScreen = container.NewStack(
+		canvas.NewRectangle(color.Black),
+		container.NewCenter(container.NewVBox(lw.timerLabel, lw.timerText)),
+	)
+	lw.timerScreen.Hide()
+
+	// Create the corner timer
+	lw.cornerText = canvas.NewText("", color.White)
+	lw.cornerText.TextStyle = fyne.TextStyle{Bold: true}
+	cornerBox := container.NewStack(
+		canvas.NewRectangle(color.NRGBA{A: 160}),
+		container.NewPadded(lw.cornerText),
+	)
+	lw.timerCorner = container.NewBorder(container.NewHBox(layout.NewSpacer(), cornerBox), nil, nil, nil)
+	lw.timerCorner.Hide()
+
+	// Create the scrolling ticker; the text is moved by an animation
+	lw.tickerText = canvas.NewText("", color.White)
+	lw.tickerText.TextStyle = fyne.TextStyle{Bold: true}
+	lw.tickerSpace = canvas.NewRectangle(color.NRGBA{A: 200})
+	lw.tickerBand = container.NewStack(lw.tickerSpace, container.NewWithoutLayout(lw.tickerText))
+	lw.tickerBand.Hide()
+
+	// Create the lower third
+	lw.lowerText = canvas.NewText("", color.White)
+	lw.lowerText.TextStyle = fyne.TextStyle{Bold: true}
+	lw.lowerThird = container.NewStack(
+		canvas.NewRectangle(color.NRGBA{R: 0x0d, G: 0x47, B: 0xa1, A: 220}),
+		container.NewPadded(container.NewCenter(lw.lowerText)),
 	)
+	lw.lowerThird.Hide()
+	lw.messageID = 0
 
-	// Set dark background
-	bg := canvas.NewRectangle(color.Black)
-	mainContent := container.NewStack(bg, content)
+	messageLayer := container.NewBorder(nil, container.NewStack(lw.tickerBand, lw.lowerThird), nil, nil)
+
+	lw.bgMutex.Lock()
+	lw.background = nil
+	lw.bgMutex.Unlock()
+
+	mainContent := container.NewStack(lw.layers()...)
+	mainContent.Add(lw.timerScreen)
+	mainContent.Add(messageLayer)
+	mainContent.Add(lw.timerCorner)
 
 	// Set the content
 	lw.window.SetContent(mainContent)
@@ -161,65 +203,166 @@ func (lw *LiveWindow) monitorWindowSize(initialSize fyne.Size) {
 			if currentTheme, ok := lw.app.Settings().Theme().(*presentationTheme); ok {
 				currentTheme.UpdateWindowSize(currentSize)
 				// Force refresh text
-				lw.verseText.Refresh()
-				lw.reference.Refresh()
+				lw.refreshText()
 			}
+			lw.showBackgroundFrame()
 			lastSize = currentSize
 		}
 	}
 }
 
+// tickOverlays refreshes the timer and message overlays until stop is closed
+func (lw *LiveWindow) tickOverlays(stop chan struct{}) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			lw.refreshTimer()
+			lw.refreshMessage()
+		}
+	}
+}
+
+// stopTicking stops refreshing the overlays of the window that was open
+func (lw *LiveWindow) stopTicking() {
+	if lw.stop != nil {
+		close(lw.stop)
+		lw.stop = nil
+	}
+}
+
+// refreshTimer shows the timer full screen, in the corner or not at all,
+// e.g. for a countdown shown only on the stage displays
+func (lw *LiveWindow) refreshTimer() {
+	status := lw.timer.Status()
+	height := lw.window.Canvas().Size().Height
+
+	if status.Running && status.Settings.Display == presentation.TimerDisplayFullScreen {
+		setCanvasText(lw.timerLabel, status.Settings.Label, height/12)
+		setCanvasText(lw.timerText, status.Text, height/4)
+		setVisible(lw.timerScreen, true)
+		setVisible(lw.content, false)
+	} else {
+		setVisible(lw.timerScreen, false)
+		setVisible(lw.content, true)
+	}
+
+	if status.Running && status.Settings.Display == presentation.TimerDisplayCorner {
+		setCanvasText(lw.cornerText, status.LabeledText(), height/16)
+		setVisible(lw.timerCorner, true)
+	} else {
+		setVisible(lw.timerCorner, false)
+	}
+}
+
+// refreshMessage shows the current message as a ticker or lower third
+func (lw *LiveWindow) refreshMessage() {
+	msg := lw.messages.Current()
+	id := 0
+	if msg != nil {
+		id = msg.ID
+	}
+	if id == lw.messageID {
+		return
+	}
+	lw.messageID = id
+
+	lw.stopTicker()
+	setVisible(lw.tickerBand, false)
+	setVisible(lw.lowerThird, false)
+	if msg == nil {
+		return
+	}
+
+	size := lw.window.Canvas().Size()
+	textSize := size.Height / 14
+	switch msg.Style {
+	case presentation.MessageTicker:
+		lw.startTicker(msg.Text, textSize, size.Width)
+	case presentation.MessageLowerThird:
+		setCanvasText(lw.lowerText, msg.Text, textSize)
+		lw.lowerThird.Show()
+	}
+}
+
+// startTicker scrolls text from right to left across the bottom of the window, repeating
+func (lw *LiveWindow) startTicker(text string, textSize, width float32) {
+	setCanvasText(lw.tickerText, text, textSize)
+	textMin := lw.tickerText.MinSize()
+	lw.tickerText.Resize(textMin)
+
+	bandHeight := textMin.Height * 1.4
+	lw.tickerSpace.SetMinSize(fyne.NewSize(0, bandHeight))
+	lw.tickerBand.Show()
+
+	// Scroll at about four text heights per second
+	distance := width + textMin.Width
+	duration := time.Duration(float32(time.Second) * distance / (textSize * 4))
+	y := (bandHeight - textMin.Height) / 2
+
+	lw.tickerAnim = fyne.NewAnimation(duration, func(progress float32) {
+		lw.tickerText.Move(fyne.NewPos(width-progress*distance, y))
+	})
+	lw.tickerAnim.Curve = fyne.AnimationLinear
+	lw.tickerAnim.RepeatCount = fyne.AnimationRepeatForever
+	lw.tickerAnim.Start()
+}
+
+// stopTicker stops the ticker animation
+func (lw *LiveWindow) stopTicker() {
+	if lw.tickerAnim != nil {
+		lw.tickerAnim.Stop()
+		lw.tickerAnim = nil
+	}
+}
+
+// setCanvasText updates a canvas text, refreshing only when it changed
+func setCanvasText(t *canvas.Text, text string, size float32) {
+	if t.Text == text && t.TextSize == size {
+		return
+	}
+	t.Text = text
+	t.TextSize = size
+	t.Refresh()
+}
+
+// setVisible shows or hides an object, doing nothing when it is already so
+func setVisible(o fyne.CanvasObject, visible bool) {
+	switch {
+	case visible && !o.Visible():
+		o.Show()
+	case !visible && o.Visible():
+		o.Hide()
+	}
+}
+
 // Close closes the live window
 func (lw *LiveWindow) Close() {
 	if lw.isOpen && lw.window != nil {
 		lw.window.Close()
 		lw.isOpen = false
+		lw.stopTicking()
 	}
 }
 
-// UpdateVerse updates the verse displayed in the live window
-func (lw *LiveWindow) UpdateVerse(verse *bible.Verse) {
-	if !lw.isOpen || verse == nil {
+// UpdateSlide updates the slide displayed in the live window
+func (lw *LiveWindow) UpdateSlide(slide *presentation.Slide) {
+	if !lw.isOpen || slide == nil {
 		return
 	}
 
-	// Update the reference; prefer localized chapter header if available
-	var referenceText string
-	if header, ok, err := bible.GetChapterHeader(verse.Translation, verse.Book, verse.Chapter); err == nil && ok && header != "" {
-		referenceText = fmt.Sprintf("%s:%d %s", header, verse.Verse, verse.Translation)
-	} else {
-		referenceText = fmt.Sprintf("%s %d:%d %s", verse.Book, verse.Chapter, verse.Verse, verse.Translation)
-	}
-	lw.reference.Segments = []widget.RichTextSegment{
-		&widget.TextSegment{
-			Style: widget.RichTextStyle{
-				TextStyle: fyne.TextStyle{
-					Bold: true,
-				},
-				SizeName:  theme.SizeNameSubHeadingText,
-				Alignment: fyne.TextAlignCenter,
-			},
-			Text: string(referenceText),
-		},
-	}
-
-	// Update the verse text with white color
-	lw.verseText.Segments = []widget.RichTextSegment{
-		&widget.TextSegment{
-			Style: widget.RichTextStyle{
-				TextStyle: fyne.TextStyle{
-					Bold: true,
-				},
-				Alignment: fyne.TextAlignCenter,
-				SizeName:  theme.SizeNameHeadingText,
-			},
-			Text: verse.Text,
-		},
-	}
-	lw.verseText.Wrapping = fyne.TextWrapWord
-
-	lw.verseText.Refresh()
-	lw.reference.Refresh()
+	// Apply the slide theme
+	st := presentation.GetTheme(slide.Theme)
+	if currentTheme, ok := lw.app.Settings().Theme().(*presentationTheme); ok {
+		currentTheme.UpdateSlideText(st.Foreground)
+		currentTheme.UpdateWordsOfChrist(st.WordsOfChrist)
+	}
+	lw.setBackground(slideBackground(lw.app.Preferences(), slide))
+	lw.setSlide(slide, lw.app.Preferences().Bool(config.PrefKeyShowSectionHeadings))
 }
 
 // SetBackground sets the background color of the live window
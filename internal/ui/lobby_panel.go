@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+)
+
+// Lobby verse sources and book scopes shown in the lobby panel
+var (
+	lobbySources   = []string{"Random Verse", "Verse of the Day", "Verse List"}
+	lobbyScopes    = []string{"Whole Bible", "Old Testament", "New Testament"}
+	lobbyTestament = map[string]string{
+		"Whole Bible":   "",
+		"Old Testament": bible.TestamentOld,
+		"New Testament": bible.TestamentNew,
+	}
+)
+
+// lobbyPanel runs the lobby screen, which shows verses on its own at a fixed interval
+type lobbyPanel struct {
+	controller    *ControllerWindow
+	sourceSelect  *widget.Select
+	scopeSelect   *widget.Select
+	booksEntry    *widget.Entry
+	listEntry     *widget.Entry
+	shuffleCheck  *widget.Check
+	intervalEntry *widget.Entry
+	status        *widget.Label
+}
+
+// newLobbyPanel creates the lobby panel for the controller window
+func newLobbyPanel(c *ControllerWindow) *lobbyPanel {
+	return &lobbyPanel{
+		controller: c,
+	}
+}
+
+// content builds the lobby panel UI
+func (p *lobbyPanel) content() fyne.CanvasObject {
+	preferences := p.controller.app.Preferences()
+
+	p.scopeSelect = widget.NewSelect(lobbyScopes, nil)
+	p.scopeSelect.SetSelected(lobbyScopes[0])
+
+	p.booksEntry = widget.NewEntry()
+	p.booksEntry.SetPlaceHolder("Books (optional, e.g., Psalms, Proverbs)")
+
+	p.listEntry = widget.NewMultiLineEntry()
+	p.listEntry.SetPlaceHolder("One reference per line, with an optional weight:\nJohn 3:16 | 3\nPsalm 23:1")
+	p.listEntry.SetMinRowsVisible(6)
+	p.listEntry.SetText(preferences.String(config.PrefKeyLobbyVerseList))
+
+	p.shuffleCheck = widget.NewCheck("Pick by weight instead of in order", nil)
+
+	p.sourceSelect = widget.NewSelect(lobbySources, func(source string) {
+		p.sourceChanged(source)
+	})
+	p.sourceSelect.SetSelected(lobbySources[0])
+
+	p.intervalEntry = widget.NewEntry()
+	p.intervalEntry.SetText("30")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Verses", p.sourceSelect),
+		widget.NewFormItem("From", p.scopeSelect),
+		widget.NewFormItem("Books", p.booksEntry),
+		widget.NewFormItem("Verse List", p.listEntry),
+		widget.NewFormItem("", p.shuffleCheck),
+		widget.NewFormItem("Seconds per Verse", p.intervalEntry),
+	)
+
+	startButton := widget.NewButton("Start Lobby", func() {
+		p.start()
+	})
+	stopButton := widget.NewButton("Stop Lobby", func() {
+		p.controller.lobby.Stop()
+		p.status.SetText("Stopped")
+	})
+
+	p.status = widget.NewLabel("Stopped")
+	p.controller.lobby.SetOnError(func(err error) {
+		p.status.SetText(fmt.Sprintf("Running, last verse failed: %v", err))
+	})
+
+	return container.NewVBox(
+		form,
+		container.NewGridWithColumns(2, startButton, stopButton),
+		container.NewHBox(widget.NewLabel("Lobby:"), p.status),
+	)
+}
+
+// sourceChanged enables the fields used by a verse source
+func (p *lobbyPanel) sourceChanged(source string) {
+	if source == "Verse List" {
+		p.scopeSelect.Disable()
+		p.booksEntry.Disable()
+		p.listEntry.Enable()
+		p.shuffleCheck.Enable()
+	} else {
+		p.scopeSelect.Enable()
+		p.booksEntry.Enable()
+		p.listEntry.Disable()
+		p.shuffleCheck.Disable()
+	}
+}
+
+// start starts the lobby from the form and takes the live window
+func (p *lobbyPanel) start() {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(p.intervalEntry.Text), 64)
+	if err != nil || seconds <= 0 {
+		dialog.ShowInformation("Error", "Please enter how many seconds to show each verse", p.controller.window)
+		return
+	}
+
+	generator, err := p.generator()
+	if err != nil {
+		dialog.ShowError(err, p.controller.window)
+		return
+	}
+
+	interval := time.Duration(seconds * float64(time.Second))
+	if err := p.controller.lobby.Start(generator, interval); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to start lobby: %w", err), p.controller.window)
+		return
+	}
+
+	if !p.controller.liveWindow.IsOpen() {
+		p.controller.goLive()
+		p.controller.liveWindow.UpdateSlide(p.controller.versePresentation.GetSlide())
+	}
+	p.status.SetText("Running")
+}
+
+// generator creates the verse generator for the chosen source
+func (p *lobbyPanel) generator() (bible.Generator, error) {
+	translation := p.controller.translationSelect.Selected
+	if translation == "" || translation == "Loading..." || translation == "No translations available" {
+		return nil, fmt.Errorf("please select a translation")
+	}
+
+	switch p.sourceSelect.Selected {
+	case "Verse List":
+		entries, err := bible.ParseWeightedList(p.listEntry.Text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verse list: %w", err)
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("please enter at least one reference in the verse list")
+		}
+		p.controller.app.Preferences().SetString(config.PrefKeyLobbyVerseList, p.listEntry.Text)
+		return bible.NewListGenerator(translation, entries, p.shuffleCheck.Checked), nil
+
+	case "Verse of the Day":
+		books, err := p.books()
+		if err != nil {
+			return nil, err
+		}
+		return bible.NewDailyGenerator(translation, books), nil
+
+	default:
+		books, err := p.books()
+		if err != nil {
+			return nil, err
+		}
+		return bible.NewRandomGenerator(translation, books), nil
+	}
+}
+
+// books returns the typed books, or the books of the chosen testament.
+// The whole Bible is an empty list.
+func (p *lobbyPanel) books() ([]string, error) {
+	if strings.TrimSpace(p.booksEntry.Text) == "" {
+		testament := lobbyTestament[p.scopeSelect.Selected]
+		if testament == "" {
+			return nil, nil
+		}
+		return bible.TestamentBooks(testament)
+	}
+
+	var books []string
+	for _, name := range strings.Split(p.booksEntry.Text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		book, ok, err := bible.ResolveBookName(name, "")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown book: %s", name)
+		}
+		books = append(books, book)
+	}
+	return books, nil
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/config"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// themeBackgroundOption is the background choice that keeps the theme's background
+const themeBackgroundOption = "Theme Background"
+
+// newDimSlider creates a slider for how much a background is darkened
+func newDimSlider() *widget.Slider {
+	slider := widget.NewSlider(0, 0.9)
+	slider.Step = 0.05
+	return slider
+}
+
+// mediaPanel lets the operator browse the media library folder and pick
+// backgrounds for slide themes or the current slide
+type mediaPanel struct {
+	controller  *ControllerWindow
+	items       []media.Item
+	selected    *media.Item
+	list        *widget.List
+	preview     *canvas.Image
+	modeSelect  *widget.Select
+	dimSlider   *widget.Slider
+	themeSelect *widget.Select
+}
+
+// newMediaPanel creates the media panel for the controller window
+func newMediaPanel(c *ControllerWindow) *mediaPanel {
+	return &mediaPanel{
+		controller: c,
+	}
+}
+
+// content builds the media panel UI
+func (p *mediaPanel) content() fyne.CanvasObject {
+	p.list = widget.NewList(
+		func() int {
+			return len(p.items)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("background.jpg (motion)")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			m := p.items[id]
+			item.(*widget.Label).SetText(fmt.Sprintf("%s (%s)", m.Name, m.Kind))
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		p.selectItem(&p.items[id])
+	}
+
+	p.preview = &canvas.Image{FillMode: canvas.ImageFillContain}
+	p.preview.SetMinSize(fyne.NewSize(320, 180))
+
+	p.modeSelect = widget.NewSelect(media.Modes, nil)
+	p.modeSelect.SetSelected(media.ModeFill)
+	p.dimSlider = newDimSlider()
+	p.themeSelect = widget.NewSelect(presentation.ThemeNames(), nil)
+	p.themeSelect.SetSelected(presentation.DefaultTheme)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Scaling", p.modeSelect),
+		widget.NewFormItem("Dim", p.dimSlider),
+		widget.NewFormItem("Theme", p.themeSelect),
+	)
+
+	themeButton := widget.NewButton("Use for Theme", func() {
+		p.useForTheme()
+	})
+	clearButton := widget.NewButton("Clear Theme Background", func() {
+		config.SaveThemeBackground(p.controller.app.Preferences(), p.themeSelect.Selected, nil)
+		p.refreshLive()
+	})
+	slideButton := widget.NewButton("Show Behind Current Slide", func() {
+		p.showBehindSlide()
+	})
+	reloadButton := widget.NewButton("Reload", func() {
+		p.controller.liveWindow.clearMediaCache()
+		go p.loadItems()
+	})
+
+	go p.loadItems()
+
+	return container.NewHSplit(
+		container.NewBorder(
+			widget.NewLabel(fmt.Sprintf("Media Folder: %s", media.GetDir())),
+			reloadButton,
+			nil,
+			nil,
+			p.list,
+		),
+		container.NewVBox(
+			p.preview,
+			form,
+			container.NewGridWithColumns(2, themeButton, clearButton),
+			slideButton,
+		),
+	)
+}
+
+// loadItems loads the media library folder into the list
+func (p *mediaPanel) loadItems() {
+	items, err := media.List()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load media library: %w", err), p.controller.window)
+		return
+	}
+
+	p.items = items
+	p.selected = nil
+	p.list.UnselectAll()
+	p.list.Refresh()
+}
+
+// selectItem previews a media file
+func (p *mediaPanel) selectItem(item *media.Item) {
+	m, err := media.Load(item.Name)
+	if err != nil {
+		dialog.ShowError(err, p.controller.window)
+		return
+	}
+
+	p.selected = item
+	p.preview.Image = m.Frame()
+	p.preview.Refresh()
+}
+
+// background returns the selected file with the chosen scaling and dimming
+func (p *mediaPanel) background() *media.Background {
+	if p.selected == nil {
+		dialog.ShowInformation("Error", "Please select a background from the media folder", p.controller.window)
+		return nil
+	}
+
+	return &media.Background{
+		File: p.selected.Name,
+		Mode: p.modeSelect.Selected,
+		Dim:  p.dimSlider.Value,
+	}
+}
+
+// useForTheme makes the selected file the background of a slide theme
+func (p *mediaPanel) useForTheme() {
+	background := p.background()
+	if background == nil {
+		return
+	}
+
+	config.SaveThemeBackground(p.controller.app.Preferences(), p.themeSelect.Selected, background)
+	p.refreshLive()
+}
+
+// showBehindSlide shows the current slide again with the selected background
+func (p *mediaPanel) showBehindSlide() {
+	background := p.background()
+	if background == nil {
+		return
+	}
+
+	current := p.controller.versePresentation.GetSlide()
+	if current == nil {
+		dialog.ShowInformation("Error", "No slide is being shown", p.controller.window)
+		return
+	}
+
+	slide := *current
+	slide.Background = background.File
+	slide.BackgroundMode = background.Mode
+	slide.BackgroundDim = background.Dim
+	p.controller.versePresentation.SetSlide(&slide)
+}
+
+// refreshLive redraws the live slide so a changed theme background shows
+func (p *mediaPanel) refreshLive() {
+	slide := p.controller.versePresentation.GetSlide()
+	if p.controller.liveWindow.IsOpen() && slide != nil {
+		p.controller.liveWindow.UpdateSlide(slide)
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// Message style names shown in the message panel
+var (
+	messageStyleNames = []string{"Ticker", "Lower Third"}
+	messageStyles     = map[string]string{
+		"Ticker":      presentation.MessageTicker,
+		"Lower Third": presentation.MessageLowerThird,
+	}
+)
+
+// messagePanel lets the operator queue notices shown over the live slide
+type messagePanel struct {
+	controller   *ControllerWindow
+	messages     []*presentation.Message // Current message first, then the queue
+	showing      bool                    // Whether the first message is being shown
+	selected     int
+	textEntry    *widget.Entry
+	styleRadio   *widget.RadioGroup
+	secondsEntry *widget.Entry
+	list         *widget.List
+}
+
+// newMessagePanel creates the message panel for the controller window
+func newMessagePanel(c *ControllerWindow) *messagePanel {
+	return &messagePanel{
+		controller: c,
+		selected:   -1,
+	}
+}
+
+// content builds the message panel UI
+func (p *messagePanel) content() fyne.CanvasObject {
+	p.textEntry = widget.NewEntry()
+	p.textEntry.SetPlaceHolder("Parent of child #12 please come to the nursery")
+	p.textEntry.OnSubmitted = func(string) {
+		p.queue()
+	}
+
+	p.styleRadio = widget.NewRadioGroup(messageStyleNames, nil)
+	p.styleRadio.Horizontal = true
+	p.styleRadio.Required = true
+	p.styleRadio.SetSelected(messageStyleNames[0])
+
+	p.secondsEntry = widget.NewEntry()
+	p.secondsEntry.SetPlaceHolder("Seconds (0 until dismissed)")
+	p.secondsEntry.SetText(strconv.Itoa(int(presentation.DefaultMessageDuration / time.Second)))
+
+	form := widget.NewForm(
+		widget.NewFormItem("Message", p.textEntry),
+		widget.NewFormItem("Style", p.styleRadio),
+		widget.NewFormItem("Seconds", p.secondsEntry),
+	)
+
+	queueButton := widget.NewButton("Queue Message", func() {
+		p.queue()
+	})
+
+	p.list = widget.NewList(
+		func() int {
+			return len(p.messages)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("Showing: message text")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			msg := p.messages[id]
+			state := "Queued"
+			if id == 0 && p.showing {
+				state = "Showing"
+			}
+			item.(*widget.Label).SetText(fmt.Sprintf("%s: %s", state, msg.Text))
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		p.selected = id
+	}
+	p.list.OnUnselected = func(widget.ListItemID) {
+		p.selected = -1
+	}
+
+	dismissButton := widget.NewButton("Dismiss Current", func() {
+		p.controller.messages.Dismiss()
+	})
+	removeButton := widget.NewButton("Remove Selected", func() {
+		p.removeSelected()
+	})
+	clearButton := widget.NewButton("Clear All", func() {
+		p.controller.messages.Clear()
+	})
+
+	p.controller.messages.SetOnChange(p.refresh)
+
+	return container.NewBorder(
+		container.NewVBox(form, queueButton, widget.NewLabel("Messages:")),
+		container.NewGridWithColumns(3, dismissButton, removeButton, clearButton),
+		nil,
+		nil,
+		p.list,
+	)
+}
+
+// queue adds the message in the form to the queue
+func (p *messagePanel) queue() {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(p.secondsEntry.Text), 64)
+	if err != nil || seconds < 0 {
+		dialog.ShowInformation("Error", "Please enter how many seconds to show the message, or 0", p.controller.window)
+		return
+	}
+
+	duration := time.Duration(seconds * float64(time.Second))
+	style := messageStyles[p.styleRadio.Selected]
+	if _, err := p.controller.messages.Add(p.textEntry.Text, style, duration); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to queue message: %w", err), p.controller.window)
+		return
+	}
+	p.textEntry.SetText("")
+}
+
+// removeSelected removes the selected message from the screen or the queue
+func (p *messagePanel) removeSelected() {
+	if p.selected < 0 || p.selected >= len(p.messages) {
+		dialog.ShowInformation("Error", "Please select a message to remove", p.controller.window)
+		return
+	}
+
+	p.controller.messages.Remove(p.messages[p.selected].ID)
+}
+
+// refresh reloads the current and queued messages into the list
+func (p *messagePanel) refresh() {
+	var messages []*presentation.Message
+	current := p.controller.messages.Current()
+	if current != nil {
+		messages = append(messages, current)
+	}
+	p.messages = append(messages, p.controller.messages.Pending()...)
+	p.showing = current != nil
+
+	p.list.UnselectAll()
+	p.list.Refresh()
+}
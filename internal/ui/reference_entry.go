@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/config"
+)
+
+// maxSuggestions is the number of suggestions shown under the reference entry
+const maxSuggestions = 8
+
+// referenceEntry is an entry for Bible references with an autocomplete dropdown.
+// Suggestions are picked with the arrow keys and Enter, or by clicking.
+type referenceEntry struct {
+	widget.Entry
+	window      fyne.Window
+	suggest     func(text string) ([]bible.Suggestion, error)
+	onPick      func(bible.Suggestion)
+	onKey       func(config.Key) bool // Runs the action bound to a key, if any
+	suggestions []bible.Suggestion
+	selected    int
+	list        *widget.List
+	popup       *widget.PopUp
+	picking     bool // Set while a picked suggestion is filled in
+	navigating  bool // Set while a suggestion is highlighted with the keyboard
+}
+
+// newReferenceEntry creates a reference entry. suggest returns the suggestions
+// for the typed text and onPick is called when a suggestion is chosen.
+func newReferenceEntry(
+	window fyne.Window,
+	suggest func(text string) ([]bible.Suggestion, error),
+	onPick func(bible.Suggestion),
+) *referenceEntry {
+	e := &referenceEntry{
+		window:   window,
+		suggest:  suggest,
+		onPick:   onPick,
+		selected: -1,
+	}
+	e.ExtendBaseWidget(e)
+	e.OnChanged = e.updateSuggestions
+
+	e.list = widget.NewList(
+		func() int {
+			return len(e.suggestions)
+		},
+		func() fyne.CanvasObject {
+			reference := widget.NewLabelWithStyle("John 3:16", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			preview := widget.NewLabel("Verse preview")
+			preview.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, reference, nil, preview)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			s := e.suggestions[id]
+			// Border containers hold the center object first, then the left one
+			row := item.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(s.Preview)
+			row.Objects[1].(*widget.Label).SetText(s.Reference)
+		},
+	)
+	e.list.OnSelected = func(id widget.ListItemID) {
+		e.selected = id
+		if !e.navigating {
+			e.pick(e.suggestions[id]) // Clicked with the mouse
+		}
+	}
+	e.popup = widget.NewPopUp(e.list, window.Canvas())
+
+	return e
+}
+
+// TypedKey handles the arrow keys, Enter and Escape while suggestions are shown,
+// and the bound keys that don't edit text
+func (e *referenceEntry) TypedKey(key *fyne.KeyEvent) {
+	if e.onKey != nil && !isEditingKey(key.Name) && !e.popup.Visible() && e.onKey(config.Key{Name: key.Name}) {
+		return
+	}
+	if !e.popup.Visible() || len(e.suggestions) == 0 {
+		e.Entry.TypedKey(key)
+		return
+	}
+
+	switch key.Name {
+	case fyne.KeyDown:
+		e.selectSuggestion(min(e.selected+1, len(e.suggestions)-1))
+	case fyne.KeyUp:
+		e.selectSuggestion(max(e.selected-1, 0))
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if e.selected >= 0 {
+			e.pick(e.suggestions[e.selected])
+			return
+		}
+		e.hideSuggestions()
+		e.Entry.TypedKey(key)
+	case fyne.KeyEscape:
+		e.hideSuggestions()
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// TypedShortcut runs the action bound to a key with modifiers before the entry's shortcuts
+func (e *referenceEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if custom, ok := shortcut.(*desktop.CustomShortcut); ok && e.onKey != nil &&
+		e.onKey(config.Key{Name: custom.KeyName, Modifier: custom.Modifier}) {
+		return
+	}
+	e.Entry.TypedShortcut(shortcut)
+}
+
+// selectSuggestion highlights a suggestion
+func (e *referenceEntry) selectSuggestion(id int) {
+	e.navigating = true
+	e.selected = id
+	e.list.Select(id)
+	e.navigating = false
+}
+
+// updateSuggestions refreshes the dropdown for the typed text
+func (e *referenceEntry) updateSuggestions(text string) {
+	if e.picking {
+		return
+	}
+
+	suggestions, err := e.suggest(text)
+	if err != nil {
+		slog.Warn("Failed to suggest references", "text", text, "error", err)
+		suggestions = nil
+	}
+
+	e.suggestions = suggestions
+	e.selected = -1
+	e.list.UnselectAll()
+	e.list.Refresh()
+
+	if len(suggestions) == 0 {
+		e.hideSuggestions()
+		return
+	}
+	e.showSuggestions()
+}
+
+// showSuggestions shows the dropdown below the entry
+func (e *referenceEntry) showSuggestions() {
+	driver := fyne.CurrentApp().Driver()
+	position := driver.AbsolutePositionForObject(e).AddXY(0, e.Size().Height)
+	rowHeight := e.list.MinSize().Height
+
+	e.popup.Resize(fyne.NewSize(e.Size().Width, rowHeight*float32(len(e.suggestions))))
+	e.popup.ShowAtPosition(position)
+	e.window.Canvas().Focus(e)
+}
+
+// hideSuggestions hides the dropdown
+func (e *referenceEntry) hideSuggestions() {
+	e.popup.Hide()
+	e.selected = -1
+}
+
+// pick fills in a suggestion and passes it on
+func (e *referenceEntry) pick(s bible.Suggestion) {
+	e.picking = true
+	e.SetText(s.Reference)
+	e.picking = false
+
+	e.hideSuggestions()
+	if e.onPick != nil {
+		e.onPick(s)
+	}
+}
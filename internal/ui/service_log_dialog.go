@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/servicelog"
+)
+
+// showServiceLogExportDialog asks for a date range and format and saves the service log
+func (c *ControllerWindow) showServiceLogExportDialog() {
+	today := time.Now().Format("2006-01-02")
+
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today)
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today)
+	formatSelect := widget.NewSelect(servicelog.Formats, nil)
+	formatSelect.SetSelected(servicelog.FormatCSV)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("From (YYYY-MM-DD)", fromEntry),
+		widget.NewFormItem("To (YYYY-MM-DD)", toEntry),
+		widget.NewFormItem("Format", formatSelect),
+	}
+
+	dialog.ShowForm("Export Service Log", "Export", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		from, to, err := servicelog.DateRange(fromEntry.Text, toEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+
+		entries, err := servicelog.GetEntries(from, to)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to read service log: %w", err), c.window)
+			return
+		}
+
+		c.saveServiceLog(entries, formatSelect.Selected, fromEntry.Text)
+	}, c.window)
+}
+
+// saveServiceLog asks where to save the exported service log and writes it
+func (c *ControllerWindow) saveServiceLog(entries []*servicelog.Entry, format, fromDate string) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, c.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if err := servicelog.Export(writer, format, entries); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export service log: %w", err), c.window)
+			return
+		}
+
+		dialog.ShowInformation(
+			"Service Log Exported",
+			fmt.Sprintf("Exported %d items to %s", len(entries), writer.URI().Name()),
+			c.window,
+		)
+	}, c.window)
+
+	saveDialog.SetFileName(fmt.Sprintf("service-log-%s.%s", fromDate, format))
+	saveDialog.Show()
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/library"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// slideEditor lets the operator type sermon points or announcements,
+// show them live and keep them in the slide library
+type slideEditor struct {
+	controller  *ControllerWindow
+	editingID   int // ID of the library slide being edited, 0 for a new slide
+	slides      []*presentation.Slide
+	list        *widget.List
+	titleEntry  *widget.Entry
+	bodyEntry   *widget.Entry
+	footerEntry *widget.Entry
+	themeSelect *widget.Select
+	bgSelect    *widget.Select
+	modeSelect  *widget.Select
+	dimSlider   *widget.Slider
+}
+
+// newSlideEditor creates the slide editor for the controller window
+func newSlideEditor(c *ControllerWindow) *slideEditor {
+	return &slideEditor{
+		controller: c,
+	}
+}
+
+// content builds the slide editor UI
+func (e *slideEditor) content() fyne.CanvasObject {
+	e.titleEntry = widget.NewEntry()
+	e.titleEntry.SetPlaceHolder("Title (e.g., Announcements)")
+
+	e.bodyEntry = widget.NewMultiLineEntry()
+	e.bodyEntry.SetPlaceHolder("Sermon point or announcement text")
+	e.bodyEntry.Wrapping = fyne.TextWrapWord
+	e.bodyEntry.SetMinRowsVisible(6)
+
+	e.footerEntry = widget.NewEntry()
+	e.footerEntry.SetPlaceHolder("Footer (optional)")
+
+	e.themeSelect = widget.NewSelect(presentation.ThemeNames(), nil)
+	e.themeSelect.SetSelected(presentation.DefaultTheme)
+
+	e.bgSelect = widget.NewSelect([]string{themeBackgroundOption}, nil)
+	e.bgSelect.SetSelected(themeBackgroundOption)
+	e.modeSelect = widget.NewSelect(media.Modes, nil)
+	e.modeSelect.SetSelected(media.ModeFill)
+	e.dimSlider = newDimSlider()
+
+	form := widget.NewForm(
+		widget.NewFormItem("Title", e.titleEntry),
+		widget.NewFormItem("Body", e.bodyEntry),
+		widget.NewFormItem("Footer", e.footerEntry),
+		widget.NewFormItem("Theme", e.themeSelect),
+		widget.NewFormItem("Background", e.bgSelect),
+		widget.NewFormItem("Scaling", e.modeSelect),
+		widget.NewFormItem("Dim", e.dimSlider),
+	)
+
+	showButton := widget.NewButton("Show Slide", func() {
+		e.show()
+	})
+	saveButton := widget.NewButton("Save to Library", func() {
+		e.save()
+	})
+	newButton := widget.NewButton("New Slide", func() {
+		e.clear()
+	})
+	deleteButton := widget.NewButton("Delete", func() {
+		e.delete()
+	})
+
+	e.list = widget.NewList(
+		func() int {
+			return len(e.slides)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Slide title")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(e.slides[id].Title)
+		},
+	)
+	e.list.OnSelected = func(id widget.ListItemID) {
+		e.edit(e.slides[id])
+	}
+
+	go e.loadSlides()
+	go e.loadMedia()
+
+	editor := container.NewBorder(
+		nil,
+		container.NewGridWithColumns(2,
+			showButton, saveButton,
+			newButton, deleteButton,
+		),
+		nil,
+		nil,
+		form,
+	)
+
+	return container.NewHSplit(
+		container.NewBorder(widget.NewLabel("Slide Library:"), nil, nil, nil, e.list),
+		editor,
+	)
+}
+
+// loadSlides loads the saved slides into the library list
+func (e *slideEditor) loadSlides() {
+	slides, err := library.GetSlides()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load slide library: %w", err), e.controller.window)
+		return
+	}
+
+	e.slides = slides
+	e.list.UnselectAll()
+	e.list.Refresh()
+}
+
+// loadMedia loads the media library into the background select
+func (e *slideEditor) loadMedia() {
+	items, err := media.List()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load media library: %w", err), e.controller.window)
+		return
+	}
+
+	options := []string{themeBackgroundOption}
+	for _, item := range items {
+		options = append(options, item.Name)
+	}
+	e.bgSelect.Options = options
+	e.bgSelect.Refresh()
+}
+
+// slideFromForm creates a slide from the editor fields
+func (e *slideEditor) slideFromForm() *presentation.Slide {
+	slide := &presentation.Slide{
+		ID:     e.editingID,
+		Title:  e.titleEntry.Text,
+		Body:   e.bodyEntry.Text,
+		Footer: e.footerEntry.Text,
+		Theme:  e.themeSelect.Selected,
+	}
+	if e.bgSelect.Selected != themeBackgroundOption {
+		slide.Background = e.bgSelect.Selected
+		slide.BackgroundMode = e.modeSelect.Selected
+		slide.BackgroundDim = e.dimSlider.Value
+	}
+	return slide
+}
+
+// edit fills the editor with a library slide
+func (e *slideEditor) edit(slide *presentation.Slide) {
+	e.editingID = slide.ID
+	e.titleEntry.SetText(slide.Title)
+	e.bodyEntry.SetText(slide.Body)
+	e.footerEntry.SetText(slide.Footer)
+	if slide.Theme == "" {
+		e.themeSelect.SetSelected(presentation.DefaultTheme)
+	} else {
+		e.themeSelect.SetSelected(slide.Theme)
+	}
+
+	if slide.Background == "" {
+		e.bgSelect.SetSelected(themeBackgroundOption)
+	} else {
+		e.bgSelect.SetSelected(slide.Background)
+	}
+	if slide.BackgroundMode == "" {
+		e.modeSelect.SetSelected(media.ModeFill)
+	} else {
+		e.modeSelect.SetSelected(slide.BackgroundMode)
+	}
+	e.dimSlider.SetValue(slide.BackgroundDim)
+}
+
+// clear empties the editor for a new slide
+func (e *slideEditor) clear() {
+	e.edit(&presentation.Slide{})
+	e.list.UnselectAll()
+}
+
+// show presents the slide in the editor
+func (e *slideEditor) show() {
+	slide := e.slideFromForm()
+	if slide.Title == "" && slide.Body == "" {
+		dialog.ShowInformation("Error", "Please enter a title or body text", e.controller.window)
+		return
+	}
+
+	e.controller.versePresentation.SetSlide(slide)
+}
+
+// save stores the slide in the editor in the library
+func (e *slideEditor) save() {
+	slide := e.slideFromForm()
+	if err := library.SaveSlide(slide); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to save slide: %w", err), e.controller.window)
+		return
+	}
+
+	e.editingID = slide.ID
+	go e.loadSlides()
+}
+
+// delete removes the slide being edited from the library
+func (e *slideEditor) delete() {
+	if e.editingID == 0 {
+		dialog.ShowInformation("Error", "Please select a saved slide to delete", e.controller.window)
+		return
+	}
+
+	dialog.ShowConfirm("Delete Slide", "Delete this slide from the library?", func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := library.DeleteSlide(e.editingID); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to delete slide: %w", err), e.controller.window)
+			return
+		}
+		e.clear()
+		go e.loadSlides()
+	}, e.controller.window)
+}
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/media"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// slideView lays out a slide over its background. The live window and
+// exported images both use it, so exports match the projector.
+type slideView struct {
+	verseText *widget.RichText
+	reference *widget.RichText
+	section   *widget.RichText // Section heading above the reference, when enabled
+	footer    *widget.RichText
+	bg        *canvas.Rectangle
+	bgImage   *canvas.Image
+	bgDim     *canvas.Rectangle
+	content   *fyne.Container // Text of the slide
+	tiles     [2]*image.RGBA  // Tiled backgrounds, drawn into in turn and reused while the size stays
+	tile      int             // Index of the tile buffer drawn into next
+}
+
+// newSlideView creates the slide layout
+func newSlideView() *slideView {
+	v := &slideView{}
+
+	v.verseText = widget.NewRichText()
+	v.verseText.Wrapping = fyne.TextWrapWord
+
+	// Make the text larger for presentation with white color
+	v.verseText.Segments = []widget.RichTextSegment{
+		&widget.TextSegment{
+			Style: widget.RichTextStyle{
+				TextStyle: fyne.TextStyle{
+					Bold: true,
+				},
+				Alignment: fyne.TextAlignCenter,
+				SizeName:  theme.SizeNameHeadingText,
+			},
+			Text: "JESUS IS KING",
+		},
+	}
+
+	v.reference = widget.NewRichText()
+	v.reference.Segments = []widget.RichTextSegment{
+		&widget.TextSegment{
+			Style: widget.RichTextStyle{
+				TextStyle: fyne.TextStyle{
+					Bold: true,
+				},
+				SizeName:  theme.SizeNameSubHeadingText,
+				Alignment: fyne.TextAlignCenter,
+			},
+			Text: " ",
+		},
+	}
+
+	v.section = widget.NewRichText()
+	v.section.Hide()
+
+	v.footer = widget.NewRichText()
+	v.footer.Wrapping = fyne.TextWrapWord
+
+	// Create the layout
+	v.content = container.NewVBox(
+		container.NewCenter(v.section),
+		container.NewCenter(v.reference),
+		widget.NewSeparator(),
+		layout.NewSpacer(),
+		container.New(layout.NewPaddedLayout(), v.verseText),
+		layout.NewSpacer(),
+		container.New(layout.NewPaddedLayout(), v.footer),
+	)
+
+	// Set dark background
+	v.bg = canvas.NewRectangle(color.Black)
+
+	// Media background with a dimming layer over it
+	v.bgImage = &canvas.Image{}
+	v.bgImage.Hide()
+	v.bgDim = canvas.NewRectangle(color.Transparent)
+	v.bgDim.Hide()
+
+	return v
+}
+
+// layers returns the background layers and the slide text, bottom first
+func (v *slideView) layers() []fyne.CanvasObject {
+	return []fyne.CanvasObject{v.bg, v.bgImage, v.bgDim, v.content}
+}
+
+// setSlide shows the text of a slide over its theme's background color.
+// The theme's text colors come from the theme the view is drawn with.
+func (v *slideView) setSlide(slide *presentation.Slide, showSection bool) {
+	v.bg.FillColor = presentation.GetTheme(slide.Theme).Background
+	v.bg.Refresh()
+
+	// Update the reference or title
+	v.reference.Segments = []widget.RichTextSegment{
+		&widget.TextSegment{
+			Style: widget.RichTextStyle{
+				TextStyle: fyne.TextStyle{
+					Bold: true,
+				},
+				SizeName:  theme.SizeNameSubHeadingText,
+				Alignment: fyne.TextAlignCenter,
+				ColorName: colorNameSlideText,
+			},
+			Text: slideText(slide.Title),
+		},
+	}
+
+	// Update the section heading
+	if slide.Section != "" && showSection {
+		v.section.Segments = []widget.RichTextSegment{
+			&widget.TextSegment{
+				Style: widget.RichTextStyle{
+					TextStyle: fyne.TextStyle{
+						Italic: true,
+					},
+					SizeName:  theme.SizeNameSubHeadingText,
+					Alignment: fyne.TextAlignCenter,
+					ColorName: colorNameSlideText,
+				},
+				Text: slide.Section,
+			},
+		}
+		v.section.Show()
+	} else {
+		v.section.Hide()
+	}
+
+	// Update the verse or body text
+	v.verseText.Segments = bodySegments(slide)
+	v.verseText.Wrapping = fyne.TextWrapWord
+
+	// Update the footer
+	v.footer.Segments = []widget.RichTextSegment{
+		&widget.TextSegment{
+			Style: widget.RichTextStyle{
+				Alignment: fyne.TextAlignCenter,
+				SizeName:  theme.SizeNameText,
+				ColorName: colorNameSlideText,
+			},
+			Text: slideText(slide.Footer),
+		},
+	}
+
+	v.refreshText()
+}
+
+// refreshText redraws the slide text, e.g. after the text size changed
+func (v *slideView) refreshText() {
+	v.verseText.Refresh()
+	v.section.Refresh()
+	v.reference.Refresh()
+	v.footer.Refresh()
+}
+
+// drawBackground shows a frame of a media background scaled to size and dimmed
+func (v *slideView) drawBackground(frame image.Image, background *media.Background, size fyne.Size) {
+	switch background.Mode {
+	case media.ModeFit:
+		v.bgImage.FillMode = canvas.ImageFillContain
+		v.bgImage.Image = frame
+	case media.ModeTile:
+		v.bgImage.FillMode = canvas.ImageFillStretch
+		v.tiles[v.tile] = media.Tile(v.tiles[v.tile], frame, int(size.Width), int(size.Height))
+		v.bgImage.Image = v.tiles[v.tile]
+		v.tile = 1 - v.tile
+	default:
+		v.bgImage.FillMode = canvas.ImageFillStretch
+		v.bgImage.Image = media.Cover(frame, size.Width, size.Height)
+	}
+	v.bgImage.Refresh()
+	v.bgImage.Show()
+
+	v.bgDim.FillColor = color.NRGBA{A: uint8(min(max(background.Dim, 0), 1) * 255)}
+	v.bgDim.Refresh()
+	v.bgDim.Show()
+}
+
+// hideBackground shows only the theme's background color
+func (v *slideView) hideBackground() {
+	v.bgImage.Hide()
+	v.bgDim.Hide()
+}
+
+// bodySegments returns the body text of a slide, with red letters and
+// italics for verses that have markup
+func bodySegments(slide *presentation.Slide) []widget.RichTextSegment {
+	style := widget.RichTextStyle{
+		TextStyle: fyne.TextStyle{
+			Bold: true,
+		},
+		Alignment: fyne.TextAlignCenter,
+		SizeName:  theme.SizeNameHeadingText,
+		ColorName: colorNameSlideText,
+	}
+
+	if !slide.IsVerse() || len(slide.Verse.Spans) == 0 {
+		return []widget.RichTextSegment{
+			&widget.TextSegment{Style: style, Text: slideText(slide.Body)},
+		}
+	}
+
+	var segments []widget.RichTextSegment
+	spaced := true // Drop leading spaces at the start and after a space
+	for _, span := range slide.Verse.Spans {
+		if span.Kind != bible.SpanText {
+			continue
+		}
+		text := collapseSpaces(span.Text)
+		if spaced {
+			text = strings.TrimLeft(text, " ")
+		}
+		if text == "" {
+			continue
+		}
+		spaced = strings.HasSuffix(text, " ")
+
+		spanStyle := style
+		if span.Red {
+			spanStyle.ColorName = colorNameWordsOfChrist
+		}
+		spanStyle.TextStyle.Italic = span.Italic
+		segments = append(segments, &widget.TextSegment{Style: spanStyle, Text: text})
+	}
+	if len(segments) == 0 {
+		return []widget.RichTextSegment{
+			&widget.TextSegment{Style: style, Text: slideText(slide.Body)},
+		}
+	}
+	return segments
+}
+
+// collapseSpaces replaces runs of whitespace with one space, keeping a space at either end
+func collapseSpaces(text string) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		if text != "" {
+			return " "
+		}
+		return ""
+	}
+	if strings.TrimLeft(text, " \t\n") != text {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text, " \t\n") != text {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// slideText keeps empty slide text one line tall so the layout doesn't jump
+func slideText(text string) string {
+	if text == "" {
+		return " "
+	}
+	return text
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+	"github.com/mr-ministry/mr-verse/internal/songs"
+)
+
+// songPanel lets the operator import songs and present their sections
+type songPanel struct {
+	controller  *ControllerWindow
+	songs       []*songs.Song
+	song        *songs.Song // Selected song with its sections loaded
+	sections    []*songs.Section
+	slides      []*presentation.Slide
+	current     int // Index of the section shown live, -1 when none
+	songList    *widget.List
+	sectionList *widget.List
+}
+
+// newSongPanel creates the song panel for the controller window
+func newSongPanel(c *ControllerWindow) *songPanel {
+	return &songPanel{
+		controller: c,
+		current:    -1,
+	}
+}
+
+// content builds the song panel UI
+func (p *songPanel) content() fyne.CanvasObject {
+	p.songList = widget.NewList(
+		func() int {
+			return len(p.songs)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Song title")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(p.songs[id].Title)
+		},
+	)
+	p.songList.OnSelected = func(id widget.ListItemID) {
+		p.selectSong(p.songs[id].ID)
+	}
+
+	p.sectionList = widget.NewList(
+		func() int {
+			return len(p.sections)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Verse 1: first line")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			section := p.sections[id]
+			firstLine, _, _ := strings.Cut(section.Text, "\n")
+			item.(*widget.Label).SetText(fmt.Sprintf("%s: %s", section.DisplayName(), firstLine))
+		},
+	)
+	p.sectionList.OnSelected = func(id widget.ListItemID) {
+		p.current = id
+		p.controller.versePresentation.SetSlide(p.slides[id])
+	}
+
+	importButton := widget.NewButton("Import Song", func() {
+		p.importSong()
+	})
+	deleteButton := widget.NewButton("Delete Song", func() {
+		p.deleteSong()
+	})
+	prevButton := widget.NewButton("Previous Section", func() {
+		p.showSection(p.current - 1)
+	})
+	nextButton := widget.NewButton("Next Section", func() {
+		p.showSection(p.current + 1)
+	})
+
+	go p.loadSongs()
+
+	return container.NewHSplit(
+		container.NewBorder(
+			widget.NewLabel("Songs:"),
+			container.NewGridWithColumns(2, importButton, deleteButton),
+			nil,
+			nil,
+			p.songList,
+		),
+		container.NewBorder(
+			widget.NewLabel("Sections:"),
+			container.NewGridWithColumns(2, prevButton, nextButton),
+			nil,
+			nil,
+			p.sectionList,
+		),
+	)
+}
+
+// loadSongs loads the song library into the song list
+func (p *songPanel) loadSongs() {
+	list, err := songs.GetSongs()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load songs: %w", err), p.controller.window)
+		return
+	}
+
+	p.songs = list
+	p.songList.UnselectAll()
+	p.songList.Refresh()
+}
+
+// selectSong loads a song's sections into the section list
+func (p *songPanel) selectSong(id int) {
+	song, err := songs.GetSong(id)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load song: %w", err), p.controller.window)
+		return
+	}
+
+	p.song = song
+	p.sections = song.ArrangedSections()
+	p.slides = song.Slides()
+	p.current = -1
+	p.sectionList.UnselectAll()
+	p.sectionList.Refresh()
+}
+
+// showSection presents the section at the given arrangement index
+// by selecting it in the section list
+func (p *songPanel) showSection(index int) {
+	if index < 0 || index >= len(p.slides) {
+		return
+	}
+
+	p.sectionList.Select(index)
+}
+
+// importSong asks for a song file and adds it to the library
+func (p *songPanel) importSong() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, p.controller.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to read song: %w", err), p.controller.window)
+			return
+		}
+
+		song, err := songs.Parse(data, reader.URI().Name())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to import song: %w", err), p.controller.window)
+			return
+		}
+
+		if err := songs.SaveSong(song); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save song: %w", err), p.controller.window)
+			return
+		}
+
+		go p.loadSongs()
+	}, p.controller.window)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter(
+		[]string{".txt", ".xml", ".cho", ".chopro", ".chordpro", ".crd"},
+	))
+	fileDialog.Show()
+}
+
+// deleteSong removes the selected song from the library
+func (p *songPanel) deleteSong() {
+	if p.song == nil {
+		dialog.ShowInformation("Error", "Please select a song to delete", p.controller.window)
+		return
+	}
+
+	song := p.song
+	dialog.ShowConfirm("Delete Song", fmt.Sprintf("Delete \"%s\" from the library?", song.Title), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := songs.DeleteSong(song.ID); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to delete song: %w", err), p.controller.window)
+			return
+		}
+
+		p.song = nil
+		p.sections = nil
+		p.slides = nil
+		p.current = -1
+		p.sectionList.Refresh()
+		go p.loadSongs()
+	}, p.controller.window)
+}
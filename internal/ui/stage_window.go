@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"image/color"
+	"log/slog"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// StageWindow represents the stage (confidence monitor) display
+// showing the current verse, the next verse, a clock and the running timer
+type StageWindow struct {
+	window        fyne.Window
+	app           fyne.App
+	timer         *presentation.Timer
+	reference     *widget.RichText
+	verseText     *widget.RichText
+	nextReference *widget.RichText
+	nextText      *widget.RichText
+	clock         *widget.RichText
+	countdownText *widget.RichText
+	stop          chan struct{} // Closed when the window closes, to stop its ticking
+	isOpen        bool
+	onClose       func()
+}
+
+// NewStageWindow creates a new stage window
+func NewStageWindow(app fyne.App, timer *presentation.Timer, onClose func()) *StageWindow {
+	return &StageWindow{
+		app:     app,
+		timer:   timer,
+		onClose: onClose,
+		isOpen:  false,
+	}
+}
+
+// IsOpen returns whether the stage window is open
+func (sw *StageWindow) IsOpen() bool {
+	return sw.isOpen
+}
+
+// Open opens the stage window
+func (sw *StageWindow) Open() {
+	if sw.isOpen {
+		sw.window.Show()
+		return
+	}
+
+	// Create the window; the operator moves it to the stage monitor
+	sw.window = sw.app.NewWindow("Mr Verse - Stage Display")
+	sw.window.SetOnClosed(func() {
+		sw.isOpen = false
+		sw.stopTicking()
+		if sw.onClose != nil {
+			sw.onClose()
+		}
+	})
+
+	// Allow toggling full screen once the window is on the right monitor
+	sw.window.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+		if event.Name == fyne.KeyF11 {
+			sw.window.SetFullScreen(!sw.window.FullScreen())
+		}
+	})
+
+	sw.setupUI()
+
+	sw.window.Resize(fyne.NewSize(1024, 768))
+	sw.window.Show()
+	sw.isOpen = true
+
+	// Keep the clock and timer ticking
+	sw.stop = make(chan struct{})
+	go sw.tick(sw.stop)
+}
+
+// setupUI creates the UI components for the stage window
+func (sw *StageWindow) setupUI() {
+	sw.reference = newStageText(" ", theme.SizeNameSubHeadingText, theme.ColorNameForeground, fyne.TextAlignLeading)
+	sw.clock = newStageText(" ", theme.SizeNameSubHeadingText, theme.ColorNamePrimary, fyne.TextAlignTrailing)
+	sw.verseText = newStageText("No verse selected", theme.SizeNameHeadingText, theme.ColorNameForeground, fyne.TextAlignLeading)
+	sw.verseText.Wrapping = fyne.TextWrapWord
+	sw.nextReference = newStageText(" ", theme.SizeNameText, theme.ColorNamePlaceHolder, fyne.TextAlignLeading)
+	sw.nextText = newStageText(" ", theme.SizeNameText, theme.ColorNamePlaceHolder, fyne.TextAlignLeading)
+	sw.nextText.Wrapping = fyne.TextWrapWord
+	sw.countdownText = newStageText(" ", theme.SizeNameHeadingText, theme.ColorNamePrimary, fyne.TextAlignCenter)
+
+	header := container.NewBorder(nil, nil, sw.reference, sw.clock)
+	next := container.NewVBox(
+		widget.NewSeparator(),
+		sw.nextReference,
+		sw.nextText,
+	)
+
+	content := container.NewBorder(
+		header,
+		container.NewVBox(next, sw.countdownText),
+		nil,
+		nil,
+		container.NewVScroll(container.New(layout.NewPaddedLayout(), sw.verseText)),
+	)
+
+	bg := canvas.NewRectangle(color.Black)
+	sw.window.SetContent(
+		container.NewThemeOverride(container.NewStack(bg, content), &stageTheme{}),
+	)
+	sw.refreshTimes()
+}
+
+// newStageText creates a single bold text segment for the stage display
+func newStageText(
+	text string,
+	size fyne.ThemeSizeName,
+	colorName fyne.ThemeColorName,
+	alignment fyne.TextAlign,
+) *widget.RichText {
+	rt := widget.NewRichText()
+	setStageText(rt, text, size, colorName, alignment)
+	return rt
+}
+
+// setStageText replaces the segments of a stage text
+func setStageText(
+	rt *widget.RichText,
+	text string,
+	size fyne.ThemeSizeName,
+	colorName fyne.ThemeColorName,
+	alignment fyne.TextAlign,
+) {
+	rt.Segments = []widget.RichTextSegment{
+		&widget.TextSegment{
+			Style: widget.RichTextStyle{
+				TextStyle: fyne.TextStyle{
+					Bold: true,
+				},
+				Alignment: alignment,
+				SizeName:  size,
+				ColorName: colorName,
+			},
+			Text: text,
+		},
+	}
+	rt.Refresh()
+}
+
+// tick updates the clock and timer every second until stop is closed
+func (sw *StageWindow) tick(stop chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sw.refreshTimes()
+		}
+	}
+}
+
+// stopTicking stops the ticking of the window that was open
+func (sw *StageWindow) stopTicking() {
+	if sw.stop != nil {
+		close(sw.stop)
+		sw.stop = nil
+	}
+}
+
+// refreshTimes redraws the clock and timer
+func (sw *StageWindow) refreshTimes() {
+	setStageText(sw.clock, time.Now().Format("15:04:05"),
+		theme.SizeNameSubHeadingText, theme.ColorNamePrimary, fyne.TextAlignTrailing)
+
+	countdownText := sw.timer.Status().LabeledText()
+	if countdownText == "" {
+		countdownText = " "
+	}
+	setStageText(sw.countdownText, countdownText,
+		theme.SizeNameHeadingText, theme.ColorNamePrimary, fyne.TextAlignCenter)
+}
+
+// Close closes the stage window
+func (sw *StageWindow) Close() {
+	if sw.isOpen && sw.window != nil {
+		sw.window.Close()
+		sw.isOpen = false
+		sw.stopTicking()
+	}
+}
+
+// UpdateSlide updates the current and next item displayed on the stage window
+func (sw *StageWindow) UpdateSlide(slide *presentation.Slide) {
+	if !sw.isOpen || slide == nil {
+		return
+	}
+
+	setStageText(sw.reference, slideText(slide.Title),
+		theme.SizeNameSubHeadingText, theme.ColorNameForeground, fyne.TextAlignLeading)
+	setStageText(sw.verseText, slideText(slide.Body),
+		theme.SizeNameHeadingText, theme.ColorNameForeground, fyne.TextAlignLeading)
+
+	// Look up the verse coming up next
+	nextReference, nextText := "Next: -", " "
+	if verse := slide.Verse; verse != nil {
+		next, err := bible.GetNextVerse(verse.Translation, verse.Book, verse.Chapter, verse.Verse)
+		if err != nil {
+			slog.Warn("Stage display could not load next verse", logging.Translation(verse.Translation),
+				logging.Reference(verse.Book, verse.Chapter, verse.Verse), "error", err)
+		} else {
+			nextReference = "Next: " + presentation.FormatReference(next)
+			nextText = next.Text
+		}
+	}
+	setStageText(sw.nextReference, nextReference,
+		theme.SizeNameText, theme.ColorNamePlaceHolder, fyne.TextAlignLeading)
+	setStageText(sw.nextText, nextText,
+		theme.SizeNameText, theme.ColorNamePlaceHolder, fyne.TextAlignLeading)
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/library"
+	"github.com/mr-ministry/mr-verse/internal/presentation"
+)
+
+// Timer mode, display and expiry names shown in the timer panel
+var (
+	timerModeNames = []string{"Countdown", "Countdown to Time", "Stopwatch", "Clock"}
+	timerModes     = map[string]string{
+		"Countdown":         presentation.TimerModeCountdown,
+		"Countdown to Time": presentation.TimerModeCountdownTo,
+		"Stopwatch":         presentation.TimerModeStopwatch,
+		"Clock":             presentation.TimerModeClock,
+	}
+	timerDisplayNames = []string{"Full Screen", "Corner", "Stage Only"}
+	timerDisplays     = map[string]string{
+		"Full Screen": presentation.TimerDisplayFullScreen,
+		"Corner":      presentation.TimerDisplayCorner,
+		"Stage Only":  presentation.TimerDisplayStage,
+	}
+	timerExpiryNames = []string{"Keep Showing 00:00", "Hide Timer", "Show Slide"}
+	timerExpiries    = map[string]string{
+		"Keep Showing 00:00": presentation.TimerExpireNone,
+		"Hide Timer":         presentation.TimerExpireHide,
+		"Show Slide":         presentation.TimerExpireSlide,
+	}
+)
+
+// timerPanel lets the operator run a countdown, stopwatch or clock on the live window
+// and the stage displays
+type timerPanel struct {
+	controller   *ControllerWindow
+	slides       []*presentation.Slide
+	modeSelect   *widget.Select
+	valueEntry   *widget.Entry
+	labelEntry   *widget.Entry
+	displayRadio *widget.RadioGroup
+	expirySelect *widget.Select
+	slideSelect  *widget.Select
+	status       *widget.Label
+}
+
+// newTimerPanel creates the timer panel for the controller window
+func newTimerPanel(c *ControllerWindow) *timerPanel {
+	return &timerPanel{
+		controller: c,
+	}
+}
+
+// content builds the timer panel UI
+func (p *timerPanel) content() fyne.CanvasObject {
+	p.valueEntry = widget.NewEntry()
+	p.modeSelect = widget.NewSelect(timerModeNames, func(name string) {
+		p.modeChanged(timerModes[name])
+	})
+
+	p.labelEntry = widget.NewEntry()
+	p.labelEntry.SetPlaceHolder("Label (e.g., Service starts in)")
+
+	p.displayRadio = widget.NewRadioGroup(timerDisplayNames, nil)
+	p.displayRadio.Horizontal = true
+	p.displayRadio.Required = true
+	p.displayRadio.SetSelected(timerDisplayNames[0])
+
+	p.slideSelect = widget.NewSelect(nil, nil)
+	p.slideSelect.PlaceHolder = "Select a library slide"
+	p.expirySelect = widget.NewSelect(timerExpiryNames, func(name string) {
+		if timerExpiries[name] == presentation.TimerExpireSlide {
+			p.slideSelect.Enable()
+			go p.loadSlides()
+		} else {
+			p.slideSelect.Disable()
+		}
+	})
+	p.expirySelect.SetSelected(timerExpiryNames[0])
+	p.modeSelect.SetSelected(timerModeNames[0])
+
+	form := widget.NewForm(
+		widget.NewFormItem("Mode", p.modeSelect),
+		widget.NewFormItem("Length or Time", p.valueEntry),
+		widget.NewFormItem("Label", p.labelEntry),
+		widget.NewFormItem("Show", p.displayRadio),
+		widget.NewFormItem("When Done", p.expirySelect),
+		widget.NewFormItem("Slide", p.slideSelect),
+	)
+
+	startButton := widget.NewButton("Start Timer", func() {
+		p.start()
+	})
+	stopButton := widget.NewButton("Stop Timer", func() {
+		p.controller.timer.Stop()
+		p.refreshStatus()
+	})
+
+	p.status = widget.NewLabel("Stopped")
+	go p.tick()
+
+	return container.NewVBox(
+		form,
+		container.NewGridWithColumns(2, startButton, stopButton),
+		container.NewHBox(widget.NewLabel("Timer:"), p.status),
+	)
+}
+
+// modeChanged updates the length or time entry for a timer mode
+func (p *timerPanel) modeChanged(mode string) {
+	switch mode {
+	case presentation.TimerModeCountdown:
+		p.valueEntry.SetPlaceHolder("Minutes (e.g., 5)")
+		p.valueEntry.Enable()
+	case presentation.TimerModeCountdownTo:
+		p.valueEntry.SetPlaceHolder("Time of day (e.g., 10:30)")
+		p.valueEntry.Enable()
+	default:
+		p.valueEntry.SetPlaceHolder("Not needed")
+		p.valueEntry.Disable()
+	}
+}
+
+// loadSlides loads the slide library into the expiry slide select
+func (p *timerPanel) loadSlides() {
+	slides, err := library.GetSlides()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load slide library: %w", err), p.controller.window)
+		return
+	}
+
+	titles := make([]string, len(slides))
+	for i, slide := range slides {
+		titles[i] = slide.Title
+	}
+	p.slides = slides
+	p.slideSelect.Options = titles
+	p.slideSelect.ClearSelected()
+	p.slideSelect.Refresh()
+}
+
+// start starts the timer from the form
+func (p *timerPanel) start() {
+	settings := presentation.TimerSettings{
+		Mode:     timerModes[p.modeSelect.Selected],
+		Label:    strings.TrimSpace(p.labelEntry.Text),
+		Display:  timerDisplays[p.displayRadio.Selected],
+		OnExpiry: timerExpiries[p.expirySelect.Selected],
+	}
+
+	value := strings.TrimSpace(p.valueEntry.Text)
+	switch settings.Mode {
+	case presentation.TimerModeCountdown:
+		minutes, err := strconv.ParseFloat(value, 64)
+		if err != nil || minutes <= 0 {
+			dialog.ShowInformation("Error", "Please enter the countdown length in minutes", p.controller.window)
+			return
+		}
+		settings.Duration = time.Duration(minutes * float64(time.Minute))
+	case presentation.TimerModeCountdownTo:
+		target, err := presentation.ParseTimeOfDay(value, time.Now())
+		if err != nil {
+			dialog.ShowError(err, p.controller.window)
+			return
+		}
+		settings.Target = target
+	}
+
+	if settings.OnExpiry == presentation.TimerExpireSlide {
+		index := p.slideSelect.SelectedIndex()
+		if index < 0 {
+			dialog.ShowInformation("Error", "Please select a slide to show when the timer ends", p.controller.window)
+			return
+		}
+		settings.ExpirySlideID = p.slides[index].ID
+	}
+
+	if err := p.controller.timer.Start(settings); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to start timer: %w", err), p.controller.window)
+		return
+	}
+	p.refreshStatus()
+}
+
+// tick keeps the timer status up to date
+func (p *timerPanel) tick() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.refreshStatus()
+	}
+}
+
+// refreshStatus shows the timer's current time in the panel
+func (p *timerPanel) refreshStatus() {
+	status := p.controller.timer.Status()
+	switch {
+	case status.Running:
+		p.status.SetText(status.Text)
+	case status.Expired:
+		p.status.SetText("Finished")
+	default:
+		p.status.SetText("Stopped")
+	}
+}
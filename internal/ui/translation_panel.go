@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// translationPanel lets the operator order, hide, rename and delete translations
+// and choose the one selected on startup
+type translationPanel struct {
+	controller   *ControllerWindow
+	translations []*bible.TranslationInfo
+	selected     string // Name of the selected translation
+	list         *widget.List
+	visibleCheck *widget.Check
+}
+
+// newTranslationPanel creates the translation manager for the controller window
+func newTranslationPanel(c *ControllerWindow) *translationPanel {
+	return &translationPanel{
+		controller: c,
+	}
+}
+
+// content builds the translation manager UI
+func (p *translationPanel) content() fyne.CanvasObject {
+	p.list = widget.NewList(
+		func() int {
+			return len(p.translations)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("NLT (en) - default, hidden")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			info := p.translations[id]
+			text := fmt.Sprintf("%s (%s)", info.Name, info.Language)
+			if info.Default {
+				text += " - default"
+			}
+			if !info.Visible {
+				text += " - hidden"
+			}
+			item.(*widget.Label).SetText(text)
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		p.selected = p.translations[id].Name
+		p.visibleCheck.SetChecked(p.translations[id].Visible)
+	}
+	p.list.OnUnselected = func(widget.ListItemID) {
+		p.selected = ""
+	}
+
+	p.visibleCheck = widget.NewCheck("Show in Translation List", func(visible bool) {
+		info := p.selectedInfo()
+		if info == nil || info.Visible == visible {
+			return
+		}
+		p.apply(bible.SetTranslationVisible(info.Name, visible), "change visibility")
+	})
+
+	upButton := widget.NewButton("Move Up", func() {
+		p.move(-1)
+	})
+	downButton := widget.NewButton("Move Down", func() {
+		p.move(1)
+	})
+	defaultButton := widget.NewButton("Set as Default", func() {
+		if info := p.selectedInfo(); info != nil {
+			p.apply(bible.SetDefaultTranslation(info.Name), "set default translation")
+		}
+	})
+	renameButton := widget.NewButton("Rename", func() {
+		p.rename()
+	})
+	deleteButton := widget.NewButton("Delete", func() {
+		p.delete()
+	})
+
+	buttons := container.NewVBox(
+		p.visibleCheck,
+		container.NewGridWithColumns(2,
+			upButton, downButton,
+			defaultButton, renameButton,
+		),
+		deleteButton,
+	)
+
+	go p.refresh()
+
+	return container.NewBorder(
+		widget.NewLabel("Translations:"),
+		buttons,
+		nil,
+		nil,
+		p.list,
+	)
+}
+
+// refresh reloads the translations, keeping the selected one selected
+func (p *translationPanel) refresh() {
+	translations, err := bible.GetTranslations()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load translations: %w", err), p.controller.window)
+		return
+	}
+
+	selected := p.selected
+	p.translations = translations
+	p.list.UnselectAll()
+	p.list.Refresh()
+	for i, info := range translations {
+		if info.Name == selected {
+			p.list.Select(i)
+			return
+		}
+	}
+}
+
+// selectedInfo returns the selected translation, showing a message when there is none
+func (p *translationPanel) selectedInfo() *bible.TranslationInfo {
+	for _, info := range p.translations {
+		if info.Name == p.selected {
+			return info
+		}
+	}
+	dialog.ShowInformation("Error", "Please select a translation", p.controller.window)
+	return nil
+}
+
+// apply shows the error of a change, or reloads the translations everywhere after it
+func (p *translationPanel) apply(err error, action string) {
+	if err != nil {
+		slog.Warn("Failed to change translations", logging.Action(action), "error", err)
+		dialog.ShowError(fmt.Errorf("failed to %s: %w", action, err), p.controller.window)
+	}
+	p.controller.translationsChanged()
+}
+
+// move moves the selected translation up or down the display order
+func (p *translationPanel) move(offset int) {
+	info := p.selectedInfo()
+	if info == nil {
+		return
+	}
+
+	names := make([]string, len(p.translations))
+	index := 0
+	for i, t := range p.translations {
+		names[i] = t.Name
+		if t == info {
+			index = i
+		}
+	}
+	target := index + offset
+	if target < 0 || target >= len(names) {
+		return
+	}
+	names[index], names[target] = names[target], names[index]
+	p.apply(bible.SetTranslationOrder(names), "reorder translations")
+}
+
+// rename asks for a new name for the selected translation
+func (p *translationPanel) rename() {
+	info := p.selectedInfo()
+	if info == nil {
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(info.Name)
+	items := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+	}
+
+	dialog.ShowForm("Rename Translation", "Rename", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		err := bible.RenameTranslation(info.Name, nameEntry.Text)
+		if err == nil {
+			p.selected = strings.TrimSpace(nameEntry.Text)
+		}
+		p.apply(err, "rename translation")
+	}, p.controller.window)
+}
+
+// delete removes the selected translation after confirmation
+func (p *translationPanel) delete() {
+	info := p.selectedInfo()
+	if info == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Delete %s and its data file? Import the file again to restore it.", info.Name)
+	dialog.ShowConfirm("Delete Translation", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		p.apply(bible.DeleteTranslation(info.Name), "delete translation")
+	}, p.controller.window)
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mr-ministry/mr-verse/internal/bible"
+	"github.com/mr-ministry/mr-verse/internal/logging"
+)
+
+// maxListedIssues is the most issues of a report listed in the verification dialog
+const maxListedIssues = 500
+
+// verifyImported checks the files of newly imported translations and shows
+// the problems found, if any
+func (c *ControllerWindow) verifyImported(translations []string) {
+	var reports []*bible.Report
+	for _, translation := range translations {
+		report, err := bible.VerifyFile(bible.TranslationFile(translation))
+		if err != nil {
+			slog.Warn("Failed to verify translation", logging.Action("verify"), logging.Translation(translation), "error", err)
+			continue
+		}
+		slog.Info("Verified translation", logging.Action("verify"), logging.Translation(translation),
+			"errors", report.Count(bible.SeverityError), "warnings", report.Count(bible.SeverityWarning))
+		if len(report.Issues) > 0 {
+			reports = append(reports, report)
+		}
+	}
+	if len(reports) > 0 {
+		c.showVerifyReports("Import Check", reports)
+	}
+}
+
+// showVerifyDialog asks for a translation and checks it in the database and,
+// when it is still there, in its data file
+func (c *ControllerWindow) showVerifyDialog() {
+	translationSelect := widget.NewSelect(c.translationSelect.Options, nil)
+	translationSelect.SetSelected(c.translationSelect.Selected)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Translation", translationSelect),
+	}
+
+	dialog.ShowForm("Verify Translation", "Verify", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		translation := translationSelect.Selected
+		report, err := bible.VerifyTranslation(translation)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to verify %s: %w", translation, err), c.window)
+			return
+		}
+		reports := []*bible.Report{report}
+
+		if _, err := os.Stat(bible.TranslationFile(translation)); err == nil {
+			fileReport, err := bible.VerifyFile(bible.TranslationFile(translation))
+			if err != nil {
+				dialog.ShowError(err, c.window)
+				return
+			}
+			reports = append(reports, fileReport)
+		}
+		c.showVerifyReports("Verify Translation", reports)
+	}, c.window)
+}
+
+// showVerifyReports lists the issues of verification reports
+func (c *ControllerWindow) showVerifyReports(title string, reports []*bible.Report) {
+	var b strings.Builder
+	for i, report := range reports {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(report.Summary() + "\n")
+		if len(report.Issues) == 0 {
+			b.WriteString("No problems found.\n")
+		}
+		for j, issue := range report.Issues {
+			if j == maxListedIssues {
+				fmt.Fprintf(&b, "... and %d more\n", len(report.Issues)-maxListedIssues)
+				break
+			}
+			b.WriteString(issue.String() + "\n")
+		}
+	}
+
+	label := widget.NewLabel(b.String())
+	label.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom(title, "Close", container.NewVScroll(label), c.window)
+	d.Resize(fyne.NewSize(640, 480))
+	d.Show()
+}
@@ -0,0 +1,49 @@
+// Package xmltext escapes and unescapes text in the XML files Mr Verse
+// reads and writes: OSIS translations, slide decks and OpenLyrics songs
+package xmltext
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Escape escapes text for XML content and attributes. Control characters,
+// which XML doesn't allow, are dropped; tabs and line breaks are kept, and
+// carriage returns are written as &#xD; as encoding/xml does.
+func Escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&quot;")
+		case '\'':
+			b.WriteString("&#39;")
+		case '\r':
+			b.WriteString("&#xD;")
+		default:
+			if r < 0x20 && r != '\t' && r != '\n' {
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Unescape decodes the entities and character references in XML text,
+// e.g. inner XML. Text that isn't valid XML is returned as it is.
+func Unescape(text string) string {
+	var out struct {
+		Text string `xml:",chardata"`
+	}
+	if err := xml.Unmarshal([]byte("<x>"+text+"</x>"), &out); err != nil {
+		return text
+	}
+	return out.Text
+}